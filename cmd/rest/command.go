@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"be-wedding/internal/importer"
+	storepgsql "be-wedding/internal/store/pgsql"
+)
+
+// runCommand executes a one-off CLI subcommand instead of starting the REST server, e.g.
+//
+//	rest -c config.toml import -file guests.csv -dry-run
+func runCommand(name string, args []string, sqlDB *sql.DB) error {
+	switch name {
+	case "import":
+		return runImportCommand(args, sqlDB)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+func runImportCommand(args []string, sqlDB *sql.DB) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	filePath := flags.String("file", "", "path to the invitation CSV file (columns: name,type,session,phone,seats,table)")
+	dryRun := flags.Bool("dry-run", false, "validate and show what would be created without inserting")
+	autoAssign := flags.Bool("auto-assign", false, "place rows without a session in the least-loaded session that fits their seats")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *filePath == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open csv file: %w", err)
+	}
+	defer file.Close()
+
+	invitationImporter := importer.NewInvitationImporter(
+		storepgsql.NewInvitation(sqlDB),
+		storepgsql.NewInvitationSession(sqlDB),
+	)
+	result, err := invitationImporter.Import(context.Background(), file, importer.ImportOptions{
+		DryRun:     *dryRun,
+		AutoAssign: *autoAssign,
+	})
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tINVITATION ID\tCODE\tNAME\tTYPE\tSEATS\tSCHEDULE\tPHONE")
+	for _, row := range result.Rows {
+		invitationID := row.Invitation.ID
+		if invitationID == "" {
+			invitationID = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			row.Line, invitationID, row.Invitation.Code, row.Invitation.Name, row.Invitation.Type, row.Invitation.MaxSeats,
+			row.Invitation.Schedule, row.Invitation.WhatsAppNumber,
+		)
+	}
+	tw.Flush()
+
+	if len(result.Errors) != 0 {
+		fmt.Println()
+		tw = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "LINE\tFIELD\tERROR")
+		for _, rowErr := range result.Errors {
+			fmt.Fprintf(tw, "%d\t%s\t%s\n", rowErr.Line, rowErr.Field, rowErr.Message)
+		}
+		tw.Flush()
+	}
+
+	action := "created"
+	if *dryRun {
+		action = "would be created (dry run)"
+	}
+	fmt.Printf("\n%d of %d rows %s, %d errors\n", len(result.Rows), result.TotalRows, action, len(result.Errors))
+
+	if len(result.Errors) != 0 {
+		return fmt.Errorf("%d rows failed to import", result.TotalRows-len(result.Rows))
+	}
+
+	return nil
+}
@@ -3,13 +3,17 @@ package main
 import (
 	"be-wedding/internal/config"
 	"be-wedding/internal/rest"
+	"be-wedding/internal/rsvp"
 	"be-wedding/pkg/logger"
 	"be-wedding/pkg/pgsql"
+	"be-wedding/pkg/qr"
+	"be-wedding/pkg/storage"
 	"be-wedding/pkg/whatsapp"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 )
 
 func main() {
@@ -17,10 +21,16 @@ func main() {
 	// LOAD APPLICATION CONFIG FROM ENVIRONMENT VARIABLES
 	// -----------------------------------------------------------------------------------------------------------------
 	cfgPath := flag.String("c", "config.toml", "path to config file")
+	migrate := flag.Bool("migrate", false, "do migration (defaults to postgres.migration in the config file)")
+	flag.Parse()
+
 	cfg, err := config.LoadEnvFromFile(*cfgPath)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	if !isFlagPassed("migrate") {
+		*migrate = cfg.PostgreSQL.Migration
+	}
 
 	// -----------------------------------------------------------------------------------------------------------------
 	// STRUCTURED LOGGER
@@ -38,14 +48,24 @@ func main() {
 		return
 	}
 
-	migrate := flag.Bool("migrate", cfg.PostgreSQL.Migration, "do migration")
 	if *migrate {
-		if migrateErr := pgsql.Migrate(sqlDB, cfg.PostgreSQL.Database); err != nil {
+		if migrateErr := pgsql.Migrate(sqlDB, cfg.PostgreSQL.Database); migrateErr != nil {
 			zlogger.Error().Err(migrateErr).Msgf("rest: migration failed to migrate: %s", migrateErr)
 			return
 		}
 	}
 
+	// -----------------------------------------------------------------------------------------------------------------
+	// CLI SUBCOMMANDS
+	// -----------------------------------------------------------------------------------------------------------------
+	if subcommand := flag.Arg(0); subcommand != "" {
+		if cmdErr := runCommand(subcommand, flag.Args()[1:], sqlDB); cmdErr != nil {
+			zlogger.Error().Err(cmdErr).Msgf("rest: %s command failed: %s", subcommand, cmdErr)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// WhatsApp Client
 	whatsAppClient, whatsAppClientErr := whatsapp.NewWhatsMeowClient(cfg.WhatsApp)
 	if whatsAppClientErr != nil {
@@ -53,11 +73,64 @@ func main() {
 		return
 	}
 
+	// Blob storage
+	blobStorage, blobStorageErr := storage.New(storageConfig(cfg.Storage))
+	if blobStorageErr != nil {
+		zlogger.Error().Err(blobStorageErr).Msgf("rest: main failed to construct storage: %s", blobStorageErr)
+		return
+	}
+
+	// QR renderer
+	qrRenderer, qrRendererErr := qr.NewRenderer(cfg.QR)
+	if qrRendererErr != nil {
+		zlogger.Error().Err(qrRendererErr).Msgf("rest: main failed to construct QR renderer: %s", qrRendererErr)
+		return
+	}
+
+	// RSVP window
+	rsvpPolicy, rsvpPolicyErr := rsvp.NewPolicy(cfg.RSVP)
+	if rsvpPolicyErr != nil {
+		zlogger.Error().Err(rsvpPolicyErr).Msgf("rest: main failed to construct RSVP policy: %s", rsvpPolicyErr)
+		return
+	}
+
 	// -----------------------------------------------------------------------------------------------------------------
 	// SERVER SETUP AND EXECUTE
 	// -----------------------------------------------------------------------------------------------------------------
-	restServerHandler := rest.New(cfg, zlogger, sqlDB, whatsAppClient)
+	restServerHandler := rest.New(cfg, zlogger, sqlDB, whatsAppClient, blobStorage, qrRenderer, rsvpPolicy)
 
 	zlogger.Info().Msgf("REST Server started on port %d", cfg.API.RESTPort)
 	http.ListenAndServe(fmt.Sprintf(":%d", cfg.API.RESTPort), restServerHandler)
 }
+
+func isFlagPassed(name string) bool {
+	passed := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+
+	return passed
+}
+
+// storageConfig maps the storage section of the config file onto the options of pkg/storage.
+func storageConfig(cfg config.Storage) storage.Config {
+	return storage.Config{
+		Backend: cfg.Backend,
+		Local: storage.LocalConfig{
+			Dir:     cfg.Local.Dir,
+			BaseURL: cfg.Local.BaseURL,
+		},
+		S3: storage.S3Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			UsePathStyle:    cfg.S3.UsePathStyle,
+			PublicURL:       cfg.S3.PublicURL,
+			URLExpiry:       cfg.S3.URLExpiry,
+		},
+	}
+}
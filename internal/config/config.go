@@ -1,9 +1,11 @@
 package config
 
 import (
+	"be-wedding/internal/rsvp"
 	"be-wedding/pkg/appinfo"
 	"be-wedding/pkg/logger"
 	"be-wedding/pkg/pgsql"
+	"be-wedding/pkg/qr"
 	"be-wedding/pkg/token"
 	"be-wedding/pkg/whatsapp"
 	"fmt"
@@ -15,6 +17,8 @@ import (
 type API struct {
 	Host     string `toml:"host"`
 	RESTPort int    `toml:"rest_port"`
+	// BaseURL is the public address of the API, used to build links returned to clients.
+	BaseURL string `toml:"base_url"`
 }
 
 type OAuth struct {
@@ -23,6 +27,34 @@ type OAuth struct {
 	RedirectURL  string `toml:"redirect_uri"`
 }
 
+// Storage selects where blobs such as QR images are kept, see pkg/storage for the meaning of each field.
+type Storage struct {
+	Backend string       `toml:"backend"`
+	Local   StorageLocal `toml:"local"`
+	S3      StorageS3    `toml:"s3"`
+}
+
+type StorageLocal struct {
+	Dir     string `toml:"dir"`
+	BaseURL string `toml:"base_url"`
+}
+
+type StorageS3 struct {
+	Endpoint        string `toml:"endpoint"`
+	Region          string `toml:"region"`
+	Bucket          string `toml:"bucket"`
+	AccessKeyID     string `toml:"access_key_id"`
+	SecretAccessKey string `toml:"secret_access_key"`
+	UsePathStyle    bool   `toml:"use_path_style"`
+	PublicURL       string `toml:"public_url"`
+	URLExpiry       int    `toml:"url_expiry"`
+}
+
+// CheckIn holds the keys gate devices send as bearer tokens to download the manifest and sync scans.
+type CheckIn struct {
+	DeviceKeys []string `toml:"device_keys"`
+}
+
 type DevSettings struct {
 	Auth bool `toml:"auth"`
 }
@@ -35,6 +67,10 @@ type Config struct {
 	OAuth        OAuth               `toml:"oauth"`
 	JWT          token.Config        `toml:"jwt"`
 	WhatsApp     whatsapp.Config     `toml:"whatsapp"`
+	Storage      Storage             `toml:"storage"`
+	QR           qr.Config           `toml:"qr"`
+	RSVP         rsvp.Config         `toml:"rsvp"`
+	CheckIn      CheckIn             `toml:"checkin"`
 	DevSettings  DevSettings         `toml:"dev"`
 }
 
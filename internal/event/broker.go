@@ -0,0 +1,73 @@
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	TypeCheckIn = "check_in"
+	TypeRSVP    = "rsvp"
+)
+
+// subscriberBufferSize is how many events a subscriber may fall behind before it starts missing them.
+const subscriberBufferSize = 64
+
+type Event struct {
+	Type string
+	Time time.Time
+	Data interface{}
+}
+
+// Broker fans out events published by the write paths to every subscriber in the process.
+// Publish never blocks: an event is dropped for a subscriber whose buffer is full.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: map[chan Event]struct{}{},
+	}
+}
+
+// Subscribe returns a channel receiving every event published from now on and a function
+// that must be called to stop the subscription, after which the channel is closed.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (b *Broker) Publish(eventType string, data interface{}) {
+	evt := Event{
+		Type: eventType,
+		Time: time.Now().UTC(),
+		Data: data,
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
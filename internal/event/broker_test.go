@@ -0,0 +1,67 @@
+package event
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBrokerPublish(t *testing.T) {
+	broker := NewBroker()
+	first, unsubscribeFirst := broker.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	broker.Publish(TypeCheckIn, "data")
+
+	for idx, ch := range []<-chan Event{first, second} {
+		select {
+		case evt := <-ch:
+			if evt.Type != TypeCheckIn || evt.Data != "data" || evt.Time.IsZero() {
+				t.Errorf("subscriber %d received %+v", idx, evt)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("subscriber %d received nothing", idx)
+		}
+	}
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	broker := NewBroker()
+	ch, unsubscribe := broker.Subscribe()
+
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Error("channel is still open after unsubscribe")
+	}
+	// Publishing without subscribers must not panic on the closed channel.
+	broker.Publish(TypeRSVP, nil)
+}
+
+func TestBrokerDropsForSlowSubscriber(t *testing.T) {
+	broker := NewBroker()
+	ch, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < subscriberBufferSize+10; i++ {
+			broker.Publish(TypeCheckIn, i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full subscriber")
+	}
+	if len(ch) != subscriberBufferSize {
+		t.Errorf("buffered %d events, want %d", len(ch), subscriberBufferSize)
+	}
+	if evt := <-ch; evt.Data != 0 {
+		t.Errorf("first event = %v, want the oldest one kept", evt.Data)
+	}
+}
@@ -0,0 +1,29 @@
+package event
+
+import "time"
+
+// CheckInData is published after a guest is checked in at the door.
+type CheckInData struct {
+	CheckInID      string    `json:"check_in_id"`
+	UserID         string    `json:"user_id"`
+	Name           string    `json:"name"`
+	InvitationID   string    `json:"invitation_id"`
+	InvitationName string    `json:"invitation_name"`
+	SessionID      string    `json:"session_id"`
+	SessionName    string    `json:"session_name"`
+	PeopleCount    int64     `json:"people_count"`
+	Gate           string    `json:"gate"`
+	ScannedBy      string    `json:"scanned_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// RSVPData is published after a guest creates, updates or cancels their RSVP. A cancellation has
+// no RSVP ID and a zero people count.
+type RSVPData struct {
+	UserRSVPID  string    `json:"user_rsvp_id"`
+	UserID      string    `json:"user_id"`
+	Action      string    `json:"action"`
+	Attendance  string    `json:"attendance,omitempty"`
+	PeopleCount int64     `json:"people_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
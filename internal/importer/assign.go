@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"strings"
+
+	"be-wedding/internal/store"
+)
+
+// sessionAutoValue may be put in the session column to explicitly ask for auto-assignment.
+const sessionAutoValue = "auto"
+
+// sessionLoad tracks the seats allocated to a session while an import is being validated,
+// so rows later in the file see the seats taken by earlier ones.
+type sessionLoad struct {
+	session        *store.InvitationSessionData
+	allocatedSeats int64
+}
+
+func newSessionLoads(loadList []*store.InvitationSessionLoadData) []*sessionLoad {
+	sessionLoads := make([]*sessionLoad, len(loadList))
+	for idx, load := range loadList {
+		sessionLoads[idx] = &sessionLoad{
+			session:        &load.Session,
+			allocatedSeats: load.AllocatedSeats,
+		}
+	}
+
+	return sessionLoads
+}
+
+// fits reports whether the session has a capacity with room for the given seats.
+// Sessions without a capacity never take part in auto-assignment.
+func (l *sessionLoad) fits(seats int64) bool {
+	return l.session.Capacity.Valid && l.allocatedSeats+seats <= l.session.Capacity.Int64
+}
+
+// lessLoaded compares the share of capacity already allocated without resorting to floats.
+// Between equally loaded sessions the one with more seats left wins.
+func (l *sessionLoad) lessLoaded(other *sessionLoad) bool {
+	left := l.allocatedSeats * other.session.Capacity.Int64
+	right := other.allocatedSeats * l.session.Capacity.Int64
+	if left != right {
+		return left < right
+	}
+
+	return l.session.Capacity.Int64-l.allocatedSeats > other.session.Capacity.Int64-other.allocatedSeats
+}
+
+// leastLoadedSession returns the session with the lowest load that still fits the seats, or nil.
+func leastLoadedSession(sessionLoads []*sessionLoad, seats int64) *sessionLoad {
+	var best *sessionLoad
+	for _, load := range sessionLoads {
+		if !load.fits(seats) {
+			continue
+		}
+		if best == nil || load.lessLoaded(best) {
+			best = load
+		}
+	}
+
+	return best
+}
+
+// findSessionLoad matches a CSV session value against the session ID or, case-insensitively, the session name.
+func findSessionLoad(sessionLoads []*sessionLoad, value string) *sessionLoad {
+	if value == "" {
+		return nil
+	}
+	for _, load := range sessionLoads {
+		if load.session.ID == value || strings.EqualFold(load.session.Name, value) {
+			return load
+		}
+	}
+
+	return nil
+}
+
+func isAutoSession(value string) bool {
+	return value == "" || strings.EqualFold(value, sessionAutoValue)
+}
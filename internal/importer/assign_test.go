@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func newTestSessionLoads(loads ...[3]interface{}) []*sessionLoad {
+	loadList := make([]*store.InvitationSessionLoadData, len(loads))
+	for idx, load := range loads {
+		session := store.InvitationSessionData{ID: load[0].(string), Name: load[0].(string)}
+		if capacity := load[1].(int); capacity > 0 {
+			session.Capacity = sql.NullInt64{Int64: int64(capacity), Valid: true}
+		}
+		loadList[idx] = &store.InvitationSessionLoadData{Session: session, AllocatedSeats: int64(load[2].(int))}
+	}
+
+	return newSessionLoads(loadList)
+}
+
+func TestLeastLoadedSession(t *testing.T) {
+	tests := []struct {
+		name  string
+		loads [][3]interface{}
+		seats int64
+		want  string
+	}{
+		{
+			name:  "lowest share of capacity",
+			loads: [][3]interface{}{{"a", 100, 60}, {"b", 50, 20}, {"c", 10, 5}},
+			seats: 2,
+			want:  "b",
+		},
+		{
+			name:  "tie goes to more seats left",
+			loads: [][3]interface{}{{"a", 10, 5}, {"b", 100, 50}},
+			seats: 1,
+			want:  "b",
+		},
+		{
+			name:  "skips sessions the seats do not fit",
+			loads: [][3]interface{}{{"a", 10, 0}, {"b", 100, 90}},
+			seats: 11,
+			want:  "",
+		},
+		{
+			name:  "exact fit",
+			loads: [][3]interface{}{{"a", 10, 6}},
+			seats: 4,
+			want:  "a",
+		},
+		{
+			name:  "unlimited sessions are never picked",
+			loads: [][3]interface{}{{"a", 0, 0}, {"b", 20, 19}},
+			seats: 1,
+			want:  "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := leastLoadedSession(newTestSessionLoads(tt.loads...), tt.seats)
+			gotID := ""
+			if got != nil {
+				gotID = got.session.ID
+			}
+			if gotID != tt.want {
+				t.Errorf("leastLoadedSession() = %q, want %q", gotID, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSessionLoad(t *testing.T) {
+	loads := newTestSessionLoads([3]interface{}{"s1", 0, 0}, [3]interface{}{"Resepsi", 0, 0})
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "s1", want: true},
+		{value: "resepsi", want: true},
+		{value: "", want: false},
+		{value: "Akad", want: false},
+	}
+	for _, tt := range tests {
+		if got := findSessionLoad(loads, tt.value); (got != nil) != tt.want {
+			t.Errorf("findSessionLoad(%q) = %v, want found %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsAutoSession(t *testing.T) {
+	for value, want := range map[string]bool{"": true, "auto": true, "AUTO": true, "Akad": false} {
+		if got := isAutoSession(value); got != want {
+			t.Errorf("isAutoSession(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestImportAutoAssign(t *testing.T) {
+	csv := "name,type,seats\nA,GROUP,6\nB,GROUP,3\nC,GROUP,6\n"
+	invitationStore := &fakeInvitationStore{}
+	result, err := newTestImporter(invitationStore).Import(context.Background(), strings.NewReader(csv), ImportOptions{AutoAssign: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	// Only s2 has a capacity (10): A takes 6, B fits in the 4 left, C does not fit anymore.
+	if len(result.Rows) != 2 || result.Rows[0].Invitation.SessionID != "s2" || result.Rows[1].Invitation.SessionID != "s2" {
+		t.Errorf("Rows = %+v", result.Rows)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Line != 4 || result.Errors[0].Field != columnSession {
+		t.Errorf("Errors = %+v", result.Errors)
+	}
+}
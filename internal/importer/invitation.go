@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"be-wedding/internal/store"
+	"be-wedding/pkg/whatsapp"
+)
+
+const (
+	columnName    = "name"
+	columnType    = "type"
+	columnSession = "session"
+	columnPhone   = "phone"
+	columnSeats   = "seats"
+	columnTable   = "table"
+)
+
+var requiredColumns = []string{columnName, columnType, columnSession}
+
+// RowError describes why a single CSV row was rejected. Line is the 1-based line number in the file,
+// where line 1 is the header.
+type RowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FileError is returned when the CSV as a whole cannot be read, such as a missing header column.
+// Other errors of Import come from the database.
+type FileError struct {
+	Err error
+}
+
+func (e *FileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// InvitationRow is a CSV row that passed validation and is ready to be inserted.
+type InvitationRow struct {
+	Line       int
+	Invitation *store.InvitationData
+}
+
+type InvitationResult struct {
+	TotalRows int
+	Rows      []InvitationRow
+	Errors    []RowError
+}
+
+// Invitations returns the invitation data of all valid rows.
+func (r *InvitationResult) Invitations() []*store.InvitationData {
+	invitations := make([]*store.InvitationData, len(r.Rows))
+	for idx, row := range r.Rows {
+		invitations[idx] = row.Invitation
+	}
+
+	return invitations
+}
+
+type ImportOptions struct {
+	// DryRun validates the file without writing anything, the result only shows what would be created.
+	DryRun bool
+	// AutoAssign places rows with an empty or "auto" session in the least-loaded session
+	// whose capacity still fits the invitation seats.
+	AutoAssign bool
+}
+
+type InvitationImporter struct {
+	invitationStore        store.Invitation
+	invitationSessionStore store.InvitationSession
+}
+
+func NewInvitationImporter(invitationStore store.Invitation, invitationSessionStore store.InvitationSession) *InvitationImporter {
+	return &InvitationImporter{
+		invitationStore:        invitationStore,
+		invitationSessionStore: invitationSessionStore,
+	}
+}
+
+// Import reads a CSV with a header row of name,type,session and optional phone, seats and table columns,
+// validates every row and inserts the valid ones in a single transaction.
+func (imp *InvitationImporter) Import(ctx context.Context, r io.Reader, opts ImportOptions) (*InvitationResult, error) {
+	result, err := imp.validate(ctx, r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun || len(result.Rows) == 0 {
+		return result, nil
+	}
+
+	if err := imp.invitationStore.InsertMany(ctx, result.Invitations()); err != nil {
+		return nil, fmt.Errorf("failed to insert invitations: %w", err)
+	}
+
+	return result, nil
+}
+
+func (imp *InvitationImporter) validate(ctx context.Context, r io.Reader, opts ImportOptions) (*InvitationResult, error) {
+	loadList, err := imp.invitationSessionStore.FindAllLoad(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation sessions: %w", err)
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, &FileError{Err: errors.New("csv file is empty")}
+		}
+		return nil, &FileError{Err: fmt.Errorf("failed to read csv header: %w", err)}
+	}
+
+	columnIndex := map[string]int{}
+	for idx, column := range header {
+		columnIndex[strings.ToLower(strings.TrimSpace(column))] = idx
+	}
+	for _, column := range requiredColumns {
+		if column == columnSession && opts.AutoAssign {
+			continue
+		}
+		if _, ok := columnIndex[column]; !ok {
+			return nil, &FileError{Err: fmt.Errorf("csv header must contain the %q column", column)}
+		}
+	}
+
+	sessionLoads := newSessionLoads(loadList)
+	result := &InvitationResult{}
+	phoneLines := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &FileError{Err: fmt.Errorf("failed to read csv: %w", err)}
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		result.TotalRows++
+
+		value := func(column string) string {
+			idx, ok := columnIndex[column]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		rowErrors := []RowError{}
+		invitation := &store.InvitationData{
+			Name:      value(columnName),
+			Type:      strings.ToUpper(value(columnType)),
+			TableName: value(columnTable),
+		}
+
+		if invitation.Name == "" {
+			rowErrors = append(rowErrors, RowError{Line: line, Field: columnName, Message: "name is required"})
+		}
+
+		if invitation.Type != store.InvitationTypeSingle && invitation.Type != store.InvitationTypeGroup {
+			rowErrors = append(rowErrors, RowError{Line: line, Field: columnType, Message: "type must be SINGLE or GROUP"})
+		}
+
+		if utf8.RuneCountInString(invitation.TableName) > store.InvitationTableNameMaxLength {
+			rowErrors = append(rowErrors, RowError{Line: line, Field: columnTable, Message: fmt.Sprintf("table must be at most %d characters", store.InvitationTableNameMaxLength)})
+		}
+
+		autoAssign := opts.AutoAssign && isAutoSession(value(columnSession))
+		session := findSessionLoad(sessionLoads, value(columnSession))
+		if session == nil && !autoAssign {
+			rowErrors = append(rowErrors, RowError{Line: line, Field: columnSession, Message: fmt.Sprintf("session %q not found", value(columnSession))})
+		}
+
+		switch seats := value(columnSeats); {
+		case seats != "":
+			invitation.MaxSeats, err = strconv.ParseInt(seats, 10, 64)
+			if err != nil || invitation.MaxSeats < 1 {
+				rowErrors = append(rowErrors, RowError{Line: line, Field: columnSeats, Message: "seats must be a positive integer"})
+			}
+		default:
+			invitation.MaxSeats = store.DefaultMaxSeats(invitation.Type)
+		}
+
+		if phone := value(columnPhone); phone != "" {
+			invitation.WhatsAppNumber, err = whatsapp.NormalizeNumber(phone)
+			if err != nil {
+				rowErrors = append(rowErrors, RowError{Line: line, Field: columnPhone, Message: err.Error()})
+			} else if firstLine, ok := phoneLines[invitation.WhatsAppNumber]; ok {
+				rowErrors = append(rowErrors, RowError{Line: line, Field: columnPhone, Message: fmt.Sprintf("phone is already used on line %d", firstLine)})
+			} else {
+				phoneLines[invitation.WhatsAppNumber] = line
+			}
+		}
+
+		if len(rowErrors) == 0 && autoAssign {
+			session = leastLoadedSession(sessionLoads, invitation.MaxSeats)
+			if session == nil {
+				rowErrors = append(rowErrors, RowError{Line: line, Field: columnSession, Message: fmt.Sprintf("no session has capacity left for %d seats", invitation.MaxSeats)})
+			}
+		}
+
+		if len(rowErrors) != 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		session.allocatedSeats += invitation.MaxSeats
+		invitation.SessionID = session.session.ID
+		invitation.Schedule = session.session.Schedule
+		result.Rows = append(result.Rows, InvitationRow{Line: line, Invitation: invitation})
+	}
+
+	return result, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+type fakeSessionStore struct {
+	store.InvitationSession
+	loadList []*store.InvitationSessionLoadData
+}
+
+func (s *fakeSessionStore) FindAllLoad(ctx context.Context) ([]*store.InvitationSessionLoadData, error) {
+	return s.loadList, nil
+}
+
+type fakeInvitationStore struct {
+	store.Invitation
+	inserted []*store.InvitationData
+	err      error
+}
+
+func (s *fakeInvitationStore) InsertMany(ctx context.Context, invitations []*store.InvitationData) error {
+	s.inserted = invitations
+	return s.err
+}
+
+func newTestImporter(invitationStore *fakeInvitationStore) *InvitationImporter {
+	sessionStore := &fakeSessionStore{loadList: []*store.InvitationSessionLoadData{
+		{Session: store.InvitationSessionData{ID: "s1", Name: "Akad", Schedule: "08:00"}},
+		{Session: store.InvitationSessionData{ID: "s2", Name: "Resepsi", Schedule: "11:00", Capacity: sql.NullInt64{Int64: 10, Valid: true}}},
+	}}
+
+	return NewInvitationImporter(invitationStore, sessionStore)
+}
+
+func TestImportRows(t *testing.T) {
+	csv := "Name,Type,Session,Phone,Seats,Table\n" +
+		"Budi,single,Akad,0812-3456-789,,\n" +
+		"\n" +
+		"Keluarga Ani,GROUP,s2,+62 813 1111 2222,4,Melati\n" +
+		",SINGLE,Akad,,,\n" +
+		"Citra,FAMILY,Akad,,,\n" +
+		"Dodi,GROUP,Akad,,,\n" +
+		"Eka,SINGLE,Unknown,,,\n" +
+		"Fajar,SINGLE,Akad,08123456789,,\n" +
+		"Gita,GROUP,Akad,,0,\n" +
+		"Hana,SINGLE,Akad,,," + strings.Repeat("é", store.InvitationTableNameMaxLength) + "\n" +
+		"Indra,SINGLE,Akad,,," + strings.Repeat("a", store.InvitationTableNameMaxLength+1) + "\n"
+
+	invitationStore := &fakeInvitationStore{}
+	result, err := newTestImporter(invitationStore).Import(context.Background(), strings.NewReader(csv), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if result.TotalRows != 10 {
+		t.Errorf("TotalRows = %d, want 10", result.TotalRows)
+	}
+	if len(result.Rows) != 4 {
+		t.Fatalf("len(Rows) = %d, want 4", len(result.Rows))
+	}
+	if len(invitationStore.inserted) != 4 {
+		t.Errorf("inserted %d invitations, want 4", len(invitationStore.inserted))
+	}
+
+	single := result.Rows[0].Invitation
+	if single.Type != store.InvitationTypeSingle || single.MaxSeats != 1 || single.SessionID != "s1" || single.WhatsAppNumber != "628123456789" {
+		t.Errorf("single row = %+v", single)
+	}
+	group := result.Rows[1].Invitation
+	if group.MaxSeats != 4 || group.SessionID != "s2" || group.Schedule != "11:00" || group.TableName != "Melati" {
+		t.Errorf("group row = %+v", group)
+	}
+	if defaultGroup := result.Rows[2].Invitation; defaultGroup.MaxSeats != store.InvitationGroupDefaultMaxSeats {
+		t.Errorf("group row without seats = %+v", defaultGroup)
+	}
+
+	wantErrors := []RowError{
+		{Line: 5, Field: columnName},
+		{Line: 6, Field: columnType},
+		{Line: 8, Field: columnSession},
+		{Line: 9, Field: columnPhone},
+		{Line: 10, Field: columnSeats},
+		{Line: 12, Field: columnTable},
+	}
+	if len(result.Errors) != len(wantErrors) {
+		t.Fatalf("Errors = %+v, want %d errors", result.Errors, len(wantErrors))
+	}
+	for idx, want := range wantErrors {
+		if got := result.Errors[idx]; got.Line != want.Line || got.Field != want.Field {
+			t.Errorf("Errors[%d] = %+v, want line %d field %s", idx, got, want.Line, want.Field)
+		}
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	invitationStore := &fakeInvitationStore{}
+	csv := "name,type,session\nBudi,SINGLE,Akad\n"
+	result, err := newTestImporter(invitationStore).Import(context.Background(), strings.NewReader(csv), ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(result.Rows) != 1 || invitationStore.inserted != nil {
+		t.Errorf("dry run rows = %d, inserted = %v", len(result.Rows), invitationStore.inserted)
+	}
+}
+
+func TestImportFileError(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{name: "empty", csv: ""},
+		{name: "missing column", csv: "name,type\nBudi,SINGLE\n"},
+		{name: "bad quote", csv: "name,type,session\n\"Budi,SINGLE,Akad\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestImporter(&fakeInvitationStore{}).Import(context.Background(), strings.NewReader(tt.csv), ImportOptions{})
+			var fileErr *FileError
+			if !errors.As(err, &fileErr) {
+				t.Errorf("Import() error = %v, want a FileError", err)
+			}
+		})
+	}
+}
+
+func TestImportStoreError(t *testing.T) {
+	storeErr := errors.New("connection refused")
+	csv := "name,type,session\nBudi,SINGLE,Akad\n"
+	_, err := newTestImporter(&fakeInvitationStore{err: storeErr}).Import(context.Background(), strings.NewReader(csv), ImportOptions{})
+
+	var fileErr *FileError
+	if !errors.Is(err, storeErr) || errors.As(err, &fileErr) {
+		t.Errorf("Import() error = %v, want the store error and no FileError", err)
+	}
+}
@@ -0,0 +1,180 @@
+// Package pass lays out printable passes for guests who cannot show their QR code on a phone.
+package pass
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"be-wedding/pkg/pdf"
+)
+
+// Guest is everything printed on a pass. TableName is left out of the pass when empty.
+type Guest struct {
+	Name           string
+	InvitationCode string
+	SessionName    string
+	Schedule       string
+	Venue          string
+	TableName      string
+	People         int64
+	QR             image.Image
+}
+
+var (
+	accentColor = color.RGBA{R: 0x6e, G: 0x51, B: 0x3b, A: 0xff}
+	textColor   = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	mutedColor  = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	cutColor    = color.RGBA{R: 0xb0, G: 0xb0, B: 0xb0, A: 0xff}
+	white       = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// Sheet layout: eight place cards per A4 page inside a 10mm print margin.
+const (
+	sheetMargin  = 10 * pdf.MM
+	sheetColumns = 2
+	sheetRows    = 4
+	cardPadding  = 12
+	cardQRSize   = 120
+)
+
+// Passes renders one A6 pass per guest, for a single guest or the guests of one invitation.
+func Passes(guests []Guest) ([]byte, error) {
+	doc := pdf.New()
+	for _, guest := range guests {
+		drawPass(doc.AddPage(pdf.A6Width, pdf.A6Height), guest)
+	}
+
+	return encode(doc)
+}
+
+// Sheet renders place cards for many guests on A4 pages with dashed cut lines, so a whole tag
+// or session can be printed at once.
+func Sheet(guests []Guest) ([]byte, error) {
+	doc := pdf.New()
+	cardWidth := (pdf.A4Width - 2*sheetMargin) / sheetColumns
+	cardHeight := (pdf.A4Height - 2*sheetMargin) / sheetRows
+	cardsPerPage := sheetColumns * sheetRows
+
+	var page *pdf.Page
+	for idx, guest := range guests {
+		slot := idx % cardsPerPage
+		if slot == 0 {
+			page = doc.AddPage(pdf.A4Width, pdf.A4Height)
+		}
+		x := sheetMargin + float64(slot%sheetColumns)*cardWidth
+		y := sheetMargin + float64(slot/sheetColumns)*cardHeight
+		drawCard(page, x, y, cardWidth, cardHeight, guest)
+	}
+
+	return encode(doc)
+}
+
+func encode(doc *pdf.Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func drawPass(page *pdf.Page, guest Guest) {
+	width := page.Width()
+	center := width / 2
+	textWidth := width - 40
+
+	page.FillRect(0, 0, width, 48, accentColor)
+	page.TextCenter(center, 30, pdf.HelveticaBold, 14, white, "GUEST PASS")
+
+	name, size := pdf.FitText(pdf.HelveticaBold, 20, 12, textWidth, guest.Name)
+	page.TextCenter(center, 82, pdf.HelveticaBold, size, textColor, name)
+
+	y := 104.0
+	for _, line := range []struct {
+		font pdf.Font
+		size float64
+		c    color.Color
+		text string
+	}{
+		{pdf.Helvetica, 11, textColor, guest.SessionName},
+		{pdf.Helvetica, 10, mutedColor, guest.Schedule},
+		{pdf.Helvetica, 10, mutedColor, guest.Venue},
+	} {
+		if line.text == "" {
+			continue
+		}
+		text, size := pdf.FitText(line.font, line.size, 7, textWidth, line.text)
+		page.TextCenter(center, y, line.font, size, line.c, text)
+		y += 15
+	}
+
+	qrSize := 170.0
+	page.Image(guest.QR, center-qrSize/2, y, qrSize, qrSize)
+	y += qrSize + 24
+
+	if guest.TableName != "" {
+		table, size := pdf.FitText(pdf.HelveticaBold, 14, 9, textWidth, "Table "+guest.TableName)
+		page.TextCenter(center, y, pdf.HelveticaBold, size, accentColor, table)
+		y += 18
+	}
+	page.TextCenter(center, y, pdf.Helvetica, 11, textColor, admits(guest.People))
+
+	page.TextCenter(center, page.Height()-28, pdf.Helvetica, 8, mutedColor, "Show this pass at the entrance")
+	if guest.InvitationCode != "" {
+		page.TextCenter(center, page.Height()-16, pdf.Helvetica, 8, mutedColor, "Invitation "+guest.InvitationCode)
+	}
+}
+
+// drawCard puts the guest name across the top of the card, the QR code below it on the left
+// and the session details on the right.
+func drawCard(page *pdf.Page, x float64, y float64, width float64, height float64, guest Guest) {
+	page.StrokeRect(x, y, width, height, 0.5, cutColor, 4)
+
+	name, size := pdf.FitText(pdf.HelveticaBold, 16, 9, width-2*cardPadding, guest.Name)
+	page.TextCenter(x+width/2, y+cardPadding+16, pdf.HelveticaBold, size, textColor, name)
+
+	qrY := y + cardPadding + 28
+	page.Image(guest.QR, x+cardPadding, qrY, cardQRSize, cardQRSize)
+
+	textX := x + cardPadding + cardQRSize + cardPadding
+	textWidth := x + width - cardPadding - textX
+	textY := qrY + 10
+	writeLines := func(font pdf.Font, size float64, c color.Color, text string, maxLines int) {
+		lines := pdf.WrapText(font, size, textWidth, text)
+		if len(lines) > maxLines {
+			// The last line takes the rest of the text and is cut short with an ellipsis.
+			lines[maxLines-1] = strings.Join(lines[maxLines-1:], " ")
+			lines = lines[:maxLines]
+		}
+		for _, line := range lines {
+			line, _ = pdf.FitText(font, size, size, textWidth, line)
+			page.Text(textX, textY, font, size, c, line)
+			textY += size + 3
+		}
+	}
+
+	writeLines(pdf.HelveticaBold, 10, textColor, guest.SessionName, 2)
+	writeLines(pdf.Helvetica, 8, mutedColor, guest.Schedule, 2)
+	writeLines(pdf.Helvetica, 8, mutedColor, guest.Venue, 2)
+	if guest.TableName != "" {
+		textY += 6
+		writeLines(pdf.HelveticaBold, 13, accentColor, "Table "+guest.TableName, 1)
+	}
+	textY += 4
+	writeLines(pdf.Helvetica, 9, textColor, admits(guest.People), 1)
+
+	if guest.InvitationCode != "" {
+		page.Text(textX, qrY+cardQRSize, pdf.Helvetica, 7, mutedColor, guest.InvitationCode)
+	}
+}
+
+func admits(people int64) string {
+	if people == 1 {
+		return "Admits 1 person"
+	}
+
+	return fmt.Sprintf("Admits %d people", people)
+}
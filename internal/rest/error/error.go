@@ -6,8 +6,20 @@ import (
 	"strings"
 )
 
+const (
+	CodeInvitationRevoked  = "INVITATION_REVOKED"
+	CodeInvitationExpired  = "INVITATION_EXPIRED"
+	CodeCheckInInvalid     = "CHECKIN_INVALID"
+	CodeAlreadyCheckedIn   = "ALREADY_CHECKED_IN"
+	CodeRedemptionExceeded = "REDEMPTION_EXCEEDED"
+	CodeOutOfStock         = "OUT_OF_STOCK"
+	CodeRSVPNotOpen        = "RSVP_NOT_OPEN"
+	CodeRSVPClosed         = "RSVP_CLOSED"
+)
+
 type Error struct {
 	StatusCode int    `json:"-"`
+	Code       string `json:"code,omitempty"`
 	Message    string `json:"message"`
 }
 
@@ -15,6 +27,13 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
 }
 
+func (err Error) WithCode(code string) Error {
+	newErr := err
+	newErr.Code = code
+
+	return newErr
+}
+
 func NotFoundError(message string) Error {
 	return Error{
 		StatusCode: http.StatusNotFound,
@@ -43,6 +62,20 @@ func BadRequestError(message string) Error {
 	}
 }
 
+func ConflictError(message string) Error {
+	return Error{
+		StatusCode: http.StatusConflict,
+		Message:    message,
+	}
+}
+
+func GoneError(message string) Error {
+	return Error{
+		StatusCode: http.StatusGone,
+		Message:    message,
+	}
+}
+
 func InternalServerError() Error {
 	return Error{
 		StatusCode: http.StatusInternalServerError,
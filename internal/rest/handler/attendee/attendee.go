@@ -0,0 +1,35 @@
+package attendee
+
+import (
+	"database/sql"
+	"net/http"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/store"
+)
+
+type AttendeeHandler interface {
+	GetAttendeeList(w http.ResponseWriter, r *http.Request)
+	GetAttendeeSummary(w http.ResponseWriter, r *http.Request)
+}
+
+type attendeeHandler struct {
+	apiCfg    config.API
+	db        *sql.DB
+	userStore store.User
+}
+
+func NewAttendeeHandler(apiCfg config.API, db *sql.DB, userStore store.User) AttendeeHandler {
+	return &attendeeHandler{
+		apiCfg:    apiCfg,
+		db:        db,
+		userStore: userStore,
+	}
+}
+
+func newAttendeeFilter(r *http.Request) store.UserRSVPAttendeeFilter {
+	return store.UserRSVPAttendeeFilter{
+		SessionID: r.URL.Query().Get("session_id"),
+		TagID:     r.URL.Query().Get("tag_id"),
+	}
+}
@@ -0,0 +1,54 @@
+package attendee
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type AttendeeItem struct {
+	response.Attendee
+	Attendance     string `json:"attendance"`
+	UserID         string `json:"user_id"`
+	UserName       string `json:"user_name"`
+	InvitationID   string `json:"invitation_id"`
+	InvitationName string `json:"invitation_name"`
+	TableName      string `json:"table_name,omitempty"`
+	SessionID      string `json:"session_id"`
+	SessionName    string `json:"session_name"`
+}
+
+type GetAttendeeListResponse struct {
+	Items []AttendeeItem `json:"items"`
+}
+
+func (handler *attendeeHandler) GetAttendeeList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	attendeeList, err := handler.userStore.FindAllRSVPAttendee(ctx, newAttendeeFilter(r))
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]AttendeeItem, len(attendeeList))
+	for idx, detail := range attendeeList {
+		items[idx] = AttendeeItem{
+			Attendee:       response.NewAttendee(detail.Attendee),
+			Attendance:     detail.Attendance,
+			UserID:         detail.UserID,
+			UserName:       detail.UserName,
+			InvitationID:   detail.InvitationID,
+			InvitationName: detail.InvitationName,
+			TableName:      detail.TableName,
+			SessionID:      detail.SessionID,
+			SessionName:    detail.SessionName,
+		}
+	}
+
+	response.Respond(w, http.StatusOK, GetAttendeeListResponse{Items: items})
+}
@@ -0,0 +1,41 @@
+package attendee
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type GetAttendeeSummaryResponse struct {
+	AttendeeCount       int64            `json:"attendee_count"`
+	AdultCount          int64            `json:"adult_count"`
+	ChildCount          int64            `json:"child_count"`
+	WheelchairCount     int64            `json:"wheelchair_count"`
+	TentativeCount      int64            `json:"tentative_count"`
+	UnlistedPeople      int64            `json:"unlisted_people"`
+	DietaryRestrictions map[string]int64 `json:"dietary_restrictions"`
+}
+
+func (handler *attendeeHandler) GetAttendeeSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	summary, err := handler.userStore.FindRSVPAttendeeSummary(ctx, newAttendeeFilter(r))
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, GetAttendeeSummaryResponse{
+		AttendeeCount:       summary.AttendeeCount,
+		AdultCount:          summary.AdultCount,
+		ChildCount:          summary.ChildCount,
+		WheelchairCount:     summary.WheelchairCount,
+		TentativeCount:      summary.TentativeCount,
+		UnlistedPeople:      summary.UnlistedPeople,
+		DietaryRestrictions: summary.DietaryRestrictions,
+	})
+}
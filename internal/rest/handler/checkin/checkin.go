@@ -0,0 +1,136 @@
+package checkin
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/event"
+	"be-wedding/internal/rest/handler/guestqr"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/token"
+)
+
+type CheckInHandler interface {
+	VerifyCheckIn(w http.ResponseWriter, r *http.Request)
+	CreateCheckIn(w http.ResponseWriter, r *http.Request)
+	SyncCheckIn(w http.ResponseWriter, r *http.Request)
+	GetCheckInManifest(w http.ResponseWriter, r *http.Request)
+	GetCheckInManifestKey(w http.ResponseWriter, r *http.Request)
+}
+
+type checkInHandler struct {
+	apiCfg       config.API
+	db           *sql.DB
+	userStore    store.User
+	checkInStore store.CheckIn
+	jwt          token.JWT
+	broker       *event.Broker
+	guestFinder  *guestqr.Finder
+}
+
+func NewCheckInHandler(apiCfg config.API, db *sql.DB, userStore store.User, checkInStore store.CheckIn, jwt token.JWT, broker *event.Broker) CheckInHandler {
+	return &checkInHandler{
+		apiCfg:       apiCfg,
+		db:           db,
+		userStore:    userStore,
+		checkInStore: checkInStore,
+		jwt:          jwt,
+		broker:       broker,
+		guestFinder:  guestqr.NewFinder(userStore, jwt),
+	}
+}
+
+type GuestData struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	WhatsAppNumber string `json:"wa_number"`
+	Status         string `json:"status"`
+}
+
+type InvitationData struct {
+	ID       string `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	MaxSeats int64  `json:"max_seats"`
+}
+
+type SessionData struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Schedule  string     `json:"schedule"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Venue     string     `json:"venue"`
+}
+
+type CheckInDataResponse struct {
+	Guest           GuestData      `json:"guest"`
+	Invitation      InvitationData `json:"invitation"`
+	Session         SessionData    `json:"session"`
+	RSVPAttendance  string         `json:"rsvp_attendance,omitempty"`
+	RSVPPeopleCount *int64         `json:"rsvp_people_count"`
+	AllowedPeople   int64          `json:"allowed_people"`
+	CheckedInPeople int64          `json:"checked_in_people"`
+}
+
+func (handler *checkInHandler) checkedIn(guest *store.UserCheckInData, checkIn *store.CheckInData) {
+	guest.User.Status = store.UserStatusCheckedIn
+	guest.CheckedInPeople += checkIn.PeopleCount
+
+	handler.broker.Publish(event.TypeCheckIn, event.CheckInData{
+		CheckInID:      checkIn.ID,
+		UserID:         guest.User.ID,
+		Name:           guest.User.Name,
+		InvitationID:   guest.Invitation.ID,
+		InvitationName: guest.Invitation.Name,
+		SessionID:      guest.Session.ID,
+		SessionName:    guest.Session.Name,
+		PeopleCount:    checkIn.PeopleCount,
+		Gate:           checkIn.Gate,
+		ScannedBy:      checkIn.ScannedBy,
+		CreatedAt:      checkIn.ScannedAt,
+	})
+}
+
+func newCheckInDataResponse(checkIn *store.UserCheckInData) CheckInDataResponse {
+	resp := CheckInDataResponse{
+		Guest: GuestData{
+			ID:             checkIn.User.ID,
+			Name:           checkIn.User.Name,
+			WhatsAppNumber: checkIn.User.WhatsAppNumber,
+			Status:         checkIn.User.Status,
+		},
+		Invitation: InvitationData{
+			ID:       checkIn.Invitation.ID,
+			Code:     checkIn.Invitation.Code,
+			Name:     checkIn.Invitation.Name,
+			Type:     checkIn.Invitation.Type,
+			Status:   checkIn.Invitation.Status,
+			MaxSeats: checkIn.Invitation.MaxSeats,
+		},
+		Session: SessionData{
+			ID:       checkIn.Session.ID,
+			Name:     checkIn.Session.Name,
+			Schedule: checkIn.Session.Schedule,
+			Venue:    checkIn.Session.Venue,
+		},
+		RSVPAttendance:  checkIn.RSVPAttendance,
+		AllowedPeople:   checkIn.AllowedPeople(),
+		CheckedInPeople: checkIn.CheckedInPeople,
+	}
+	if checkIn.Session.StartTime.Valid {
+		resp.Session.StartTime = &checkIn.Session.StartTime.Time
+	}
+	if checkIn.Session.EndTime.Valid {
+		resp.Session.EndTime = &checkIn.Session.EndTime.Time
+	}
+	if checkIn.RSVPPeopleCount.Valid {
+		resp.RSVPPeopleCount = &checkIn.RSVPPeopleCount.Int64
+	}
+
+	return resp
+}
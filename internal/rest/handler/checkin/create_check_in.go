@@ -0,0 +1,127 @@
+package checkin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+type CreateCheckInRequest struct {
+	Payload     string `json:"payload"`
+	UserID      string `json:"user_id"`
+	PeopleCount int64  `json:"people_count"`
+	Gate        string `json:"gate"`
+	ScannedBy   string `json:"scanned_by"`
+}
+
+func (r *CreateCheckInRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.Payload = strings.TrimSpace(r.Payload)
+	r.UserID = strings.TrimSpace(r.UserID)
+	r.Gate = strings.TrimSpace(r.Gate)
+	r.ScannedBy = strings.TrimSpace(r.ScannedBy)
+
+	if (r.Payload == "") == (r.UserID == "") {
+		fieldErr = fieldErr.WithField("payload", "exactly one of payload or user_id is required")
+	}
+
+	if r.PeopleCount < 0 {
+		fieldErr = fieldErr.WithField("people_count", "people_count must be a positive integer")
+	}
+
+	if r.ScannedBy == "" {
+		fieldErr = fieldErr.WithField("scanned_by", "scanned_by is required")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+type CheckInResponse struct {
+	ID          string    `json:"id"`
+	PeopleCount int64     `json:"people_count"`
+	Gate        string    `json:"gate"`
+	ScannedBy   string    `json:"scanned_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateCheckInResponse struct {
+	CheckIn CheckInResponse     `json:"check_in"`
+	Guest   CheckInDataResponse `json:"guest"`
+}
+
+func (handler *checkInHandler) CreateCheckIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := CreateCheckInRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	guest, apiErr := handler.guestFinder.Find(ctx, req.Payload, req.UserID)
+	if apiErr != nil {
+		response.Error(w, *apiErr)
+		return
+	}
+
+	checkIn := &store.CheckInData{
+		UserID:      guest.User.ID,
+		PeopleCount: req.PeopleCount,
+		Gate:        req.Gate,
+		ScannedBy:   req.ScannedBy,
+	}
+	if checkIn.PeopleCount == 0 {
+		checkIn.PeopleCount = guest.AllowedPeople() - guest.CheckedInPeople
+	}
+
+	if err := handler.checkInStore.Insert(ctx, checkIn); err != nil {
+		var quotaErr *store.CheckInQuotaError
+		switch {
+		case errors.Is(err, store.ErrAlreadyCheckedIn):
+			response.Error(w, apierror.ConflictError("Guest has already checked in").WithCode(apierror.CodeAlreadyCheckedIn))
+		case errors.As(err, &quotaErr):
+			response.Error(w, apierror.BadRequestError(fmt.Sprintf(
+				"Only %d of %d people are left to check in for this guest", quotaErr.PeopleLeft, quotaErr.AllowedPeople,
+			)))
+		default:
+			log.Println("error insert check-in data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	handler.checkedIn(guest, checkIn)
+
+	resp := CreateCheckInResponse{
+		CheckIn: CheckInResponse{
+			ID:          checkIn.ID,
+			PeopleCount: checkIn.PeopleCount,
+			Gate:        checkIn.Gate,
+			ScannedBy:   checkIn.ScannedBy,
+			CreatedAt:   checkIn.CreatedAt,
+		},
+		Guest: newCheckInDataResponse(guest),
+	}
+
+	response.Respond(w, http.StatusCreated, resp)
+}
@@ -0,0 +1,51 @@
+package checkin
+
+import (
+	"testing"
+
+	apierror "be-wedding/internal/rest/error"
+)
+
+func fieldNames(fieldErr *apierror.FieldError) map[string]bool {
+	names := map[string]bool{}
+	for _, field := range fieldErr.Fields {
+		names[field.Name] = true
+	}
+
+	return names
+}
+
+func TestCreateCheckInRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateCheckInRequest
+		wantErr []string
+	}{
+		{name: "payload", req: CreateCheckInRequest{Payload: "token", ScannedBy: "usher"}},
+		{name: "user id with partial arrival", req: CreateCheckInRequest{UserID: "u1", PeopleCount: 2, ScannedBy: "usher"}},
+		{name: "neither payload nor user id", req: CreateCheckInRequest{ScannedBy: "usher"}, wantErr: []string{"payload"}},
+		{name: "both payload and user id", req: CreateCheckInRequest{Payload: "token", UserID: "u1", ScannedBy: "usher"}, wantErr: []string{"payload"}},
+		{name: "negative people and no usher", req: CreateCheckInRequest{UserID: "u1", PeopleCount: -1, ScannedBy: " "}, wantErr: []string{"people_count", "scanned_by"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) == 0 {
+				if fieldErr != nil {
+					t.Errorf("validate() = %v", fieldErr.Fields)
+				}
+				return
+			}
+			if fieldErr == nil {
+				t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+			}
+			names := fieldNames(fieldErr)
+			for _, field := range tt.wantErr {
+				if !names[field] {
+					t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+				}
+			}
+		})
+	}
+}
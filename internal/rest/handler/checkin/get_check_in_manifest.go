@@ -0,0 +1,67 @@
+package checkin
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/token"
+
+	"be-wedding/internal/rest/response"
+)
+
+type GetCheckInManifestResponse struct {
+	Manifest   string    `json:"manifest"`
+	EntryCount int       `json:"entry_count"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (handler *checkInHandler) GetCheckInManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	guestList, err := handler.userStore.FindAllCheckInData(ctx, store.UserCheckInFilter{})
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	entries := make([]token.CheckInManifestEntry, len(guestList))
+	for idx, guest := range guestList {
+		checkInToken, err := handler.jwt.CreateCheckInToken(token.CheckInClaim{
+			UserID:       guest.User.ID,
+			InvitationID: guest.Invitation.ID,
+		})
+		if err != nil {
+			log.Println("error create check-in token: %w", err)
+			response.Error(w, apierror.InternalServerError())
+			return
+		}
+
+		entries[idx] = token.CheckInManifestEntry{
+			Hash:            token.CheckInTokenHash(checkInToken),
+			UserID:          guest.User.ID,
+			Name:            guest.User.Name,
+			SessionID:       guest.Session.ID,
+			AllowedPeople:   guest.AllowedPeople(),
+			CheckedInPeople: guest.CheckedInPeople,
+		}
+	}
+
+	manifest, expiresAt, err := handler.jwt.CreateCheckInManifest(entries)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp := GetCheckInManifestResponse{
+		Manifest:   manifest,
+		EntryCount: len(entries),
+		ExpiresAt:  expiresAt,
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
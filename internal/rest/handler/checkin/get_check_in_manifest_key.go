@@ -0,0 +1,31 @@
+package checkin
+
+import (
+	"encoding/base64"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/rest/response"
+)
+
+type GetCheckInManifestKeyResponse struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+func (handler *checkInHandler) GetCheckInManifestKey(w http.ResponseWriter, r *http.Request) {
+	publicKey, err := handler.jwt.CheckInManifestPublicKey()
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp := GetCheckInManifestKeyResponse{
+		Algorithm: "EdDSA",
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
@@ -0,0 +1,256 @@
+package checkin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+const maxSyncScans = 500
+
+const (
+	SyncStatusApplied   = "APPLIED"
+	SyncStatusDuplicate = "DUPLICATE"
+	SyncStatusConflict  = "CONFLICT"
+	SyncStatusRejected  = "REJECTED"
+)
+
+type SyncScan struct {
+	ScanID      string `json:"scan_id"`
+	Payload     string `json:"payload"`
+	UserID      string `json:"user_id"`
+	PeopleCount int64  `json:"people_count"`
+	Gate        string `json:"gate"`
+	ScannedBy   string `json:"scanned_by"`
+	ScannedAt   string `json:"scanned_at"`
+
+	scannedAt time.Time
+}
+
+type SyncCheckInRequest struct {
+	DeviceID string     `json:"device_id"`
+	Scans    []SyncScan `json:"scans"`
+}
+
+func (r *SyncCheckInRequest) validate() *apierror.FieldError {
+	var err error
+	fieldErr := apierror.NewFieldError()
+
+	r.DeviceID = strings.TrimSpace(r.DeviceID)
+
+	if r.DeviceID == "" {
+		fieldErr = fieldErr.WithField("device_id", "device_id is required")
+	}
+
+	if len(r.Scans) == 0 || len(r.Scans) > maxSyncScans {
+		fieldErr = fieldErr.WithField("scans", fmt.Sprintf("scans must contain between 1 and %d scans", maxSyncScans))
+	}
+
+	scanIDs := map[string]bool{}
+	for idx := range r.Scans {
+		scan := &r.Scans[idx]
+		field := fmt.Sprintf("scans[%d]", idx)
+
+		scan.ScanID = strings.TrimSpace(scan.ScanID)
+		scan.Payload = strings.TrimSpace(scan.Payload)
+		scan.UserID = strings.TrimSpace(scan.UserID)
+		scan.Gate = strings.TrimSpace(scan.Gate)
+		scan.ScannedBy = strings.TrimSpace(scan.ScannedBy)
+
+		if scan.ScanID == "" {
+			fieldErr = fieldErr.WithField(field+".scan_id", "scan_id is required")
+		} else if scanIDs[scan.ScanID] {
+			fieldErr = fieldErr.WithField(field+".scan_id", "scan_id is repeated in this batch")
+		}
+		scanIDs[scan.ScanID] = true
+
+		if (scan.Payload == "") == (scan.UserID == "") {
+			fieldErr = fieldErr.WithField(field+".payload", "exactly one of payload or user_id is required")
+		}
+
+		if scan.PeopleCount < 0 {
+			fieldErr = fieldErr.WithField(field+".people_count", "people_count must be a positive integer")
+		}
+
+		if scan.ScannedBy == "" {
+			fieldErr = fieldErr.WithField(field+".scanned_by", "scanned_by is required")
+		}
+
+		scan.scannedAt, err = time.Parse(time.RFC3339, scan.ScannedAt)
+		if err != nil {
+			fieldErr = fieldErr.WithField(field+".scanned_at", "scanned_at must be in RFC3339 format")
+		}
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+type SyncConflict struct {
+	CheckInID   string    `json:"check_in_id"`
+	DeviceID    string    `json:"device_id"`
+	Gate        string    `json:"gate"`
+	ScannedBy   string    `json:"scanned_by"`
+	PeopleCount int64     `json:"people_count"`
+	ScannedAt   time.Time `json:"scanned_at"`
+}
+
+type SyncScanResult struct {
+	ScanID    string         `json:"scan_id"`
+	Status    string         `json:"status"`
+	UserID    string         `json:"user_id,omitempty"`
+	CheckInID string         `json:"check_in_id,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Conflicts []SyncConflict `json:"conflicts,omitempty"`
+}
+
+type SyncCheckInResponse struct {
+	DeviceID   string           `json:"device_id"`
+	Applied    int              `json:"applied"`
+	Duplicates int              `json:"duplicates"`
+	Conflicts  int              `json:"conflicts"`
+	Rejected   int              `json:"rejected"`
+	Results    []SyncScanResult `json:"results"`
+}
+
+func (handler *checkInHandler) SyncCheckIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := SyncCheckInRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	scans := req.Scans
+	sort.SliceStable(scans, func(i, j int) bool {
+		if !scans[i].scannedAt.Equal(scans[j].scannedAt) {
+			return scans[i].scannedAt.Before(scans[j].scannedAt)
+		}
+		return scans[i].ScanID < scans[j].ScanID
+	})
+
+	resp := SyncCheckInResponse{
+		DeviceID: req.DeviceID,
+		Results:  make([]SyncScanResult, len(scans)),
+	}
+	for idx, scan := range scans {
+		result, err := handler.syncScan(ctx, req.DeviceID, scan)
+		if err != nil {
+			log.Println("error sync check-in data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+			return
+		}
+
+		switch result.Status {
+		case SyncStatusApplied:
+			resp.Applied++
+		case SyncStatusDuplicate:
+			resp.Duplicates++
+		case SyncStatusConflict:
+			resp.Conflicts++
+		case SyncStatusRejected:
+			resp.Rejected++
+		}
+		resp.Results[idx] = result
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
+
+func (handler *checkInHandler) syncScan(ctx context.Context, deviceID string, scan SyncScan) (SyncScanResult, error) {
+	result := SyncScanResult{ScanID: scan.ScanID}
+
+	guest, apiErr := handler.guestFinder.Find(ctx, scan.Payload, scan.UserID)
+	if apiErr != nil {
+		if apiErr.StatusCode == http.StatusInternalServerError {
+			return result, errors.New(apiErr.Message)
+		}
+		result.Status = SyncStatusRejected
+		result.Message = apiErr.Message
+		return result, nil
+	}
+	result.UserID = guest.User.ID
+
+	checkIn := &store.CheckInData{
+		UserID:      guest.User.ID,
+		PeopleCount: scan.PeopleCount,
+		Gate:        scan.Gate,
+		ScannedBy:   scan.ScannedBy,
+		DeviceID:    deviceID,
+		ScanID:      scan.ScanID,
+		ScannedAt:   scan.scannedAt.UTC(),
+	}
+	if checkIn.PeopleCount == 0 {
+		checkIn.PeopleCount = guest.AllowedPeople() - guest.CheckedInPeople
+	}
+
+	err := handler.checkInStore.Insert(ctx, checkIn)
+	var quotaErr *store.CheckInQuotaError
+	switch {
+	case err == nil:
+		handler.checkedIn(guest, checkIn)
+		result.Status = SyncStatusApplied
+		result.CheckInID = checkIn.ID
+	case errors.Is(err, store.ErrDuplicateScan):
+		result.Status = SyncStatusDuplicate
+		result.CheckInID = checkIn.ID
+	case errors.Is(err, store.ErrAlreadyCheckedIn):
+		result.Status = SyncStatusConflict
+		result.Message = "Guest has already checked in"
+		if result.Conflicts, err = handler.syncConflicts(ctx, guest.User.ID); err != nil {
+			return result, err
+		}
+	case errors.As(err, &quotaErr):
+		result.Status = SyncStatusConflict
+		result.Message = fmt.Sprintf("Only %d of %d people are left to check in for this guest", quotaErr.PeopleLeft, quotaErr.AllowedPeople)
+		if result.Conflicts, err = handler.syncConflicts(ctx, guest.User.ID); err != nil {
+			return result, err
+		}
+	default:
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (handler *checkInHandler) syncConflicts(ctx context.Context, userID string) ([]SyncConflict, error) {
+	checkInList, err := handler.checkInStore.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]SyncConflict, len(checkInList))
+	for idx, checkIn := range checkInList {
+		conflicts[idx] = SyncConflict{
+			CheckInID:   checkIn.ID,
+			DeviceID:    checkIn.DeviceID,
+			Gate:        checkIn.Gate,
+			ScannedBy:   checkIn.ScannedBy,
+			PeopleCount: checkIn.PeopleCount,
+			ScannedAt:   checkIn.ScannedAt,
+		}
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,33 @@
+package checkin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type VerifyCheckInRequest struct {
+	Payload string `json:"payload"`
+}
+
+func (handler *checkInHandler) VerifyCheckIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := VerifyCheckInRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	checkIn, apiErr := handler.guestFinder.Verify(ctx, req.Payload)
+	if apiErr != nil {
+		response.Error(w, *apiErr)
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newCheckInDataResponse(checkIn))
+}
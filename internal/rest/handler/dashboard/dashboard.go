@@ -0,0 +1,120 @@
+package dashboard
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/event"
+	"be-wedding/internal/store"
+)
+
+const latestCheckInLimit = 20
+
+type DashboardHandler interface {
+	GetAttendanceSummary(w http.ResponseWriter, r *http.Request)
+	StreamAttendance(w http.ResponseWriter, r *http.Request)
+}
+
+type dashboardHandler struct {
+	apiCfg       config.API
+	db           *sql.DB
+	checkInStore store.CheckIn
+	broker       *event.Broker
+}
+
+func NewDashboardHandler(apiCfg config.API, db *sql.DB, checkInStore store.CheckIn, broker *event.Broker) DashboardHandler {
+	return &dashboardHandler{
+		apiCfg:       apiCfg,
+		db:           db,
+		checkInStore: checkInStore,
+		broker:       broker,
+	}
+}
+
+type AttendanceCountItem struct {
+	ID               string `json:"id,omitempty"`
+	Name             string `json:"name,omitempty"`
+	MaxSeats         int64  `json:"max_seats"`
+	ExpectedPeople   int64  `json:"expected_people"`
+	ArrivedPeople    int64  `json:"arrived_people"`
+	DeclinedUsers    int64  `json:"declined_users"`
+	MaybeUserCount   int64  `json:"maybe_user_count"`
+	MaybePeopleCount int64  `json:"maybe_people_count"`
+	PendingUsers     int64  `json:"pending_users"`
+}
+
+type AttendanceSummaryResponse struct {
+	Total          AttendanceCountItem   `json:"total"`
+	Sessions       []AttendanceCountItem `json:"sessions"`
+	Tags           []AttendanceCountItem `json:"tags"`
+	LatestCheckIns []event.CheckInData   `json:"latest_check_ins"`
+}
+
+func newAttendanceCountItems(attendanceList []store.AttendanceCountData) []AttendanceCountItem {
+	items := make([]AttendanceCountItem, len(attendanceList))
+	for idx, attendance := range attendanceList {
+		items[idx] = AttendanceCountItem{
+			ID:               attendance.ID,
+			Name:             attendance.Name,
+			MaxSeats:         attendance.MaxSeats,
+			ExpectedPeople:   attendance.ExpectedPeople,
+			ArrivedPeople:    attendance.ArrivedPeople,
+			DeclinedUsers:    attendance.DeclinedUsers,
+			MaybeUserCount:   attendance.MaybeUsers,
+			MaybePeopleCount: attendance.MaybePeople,
+			PendingUsers:     attendance.PendingUsers,
+		}
+	}
+
+	return items
+}
+
+func newCheckInEventData(detail *store.CheckInDetailData) event.CheckInData {
+	return event.CheckInData{
+		CheckInID:      detail.CheckIn.ID,
+		UserID:         detail.CheckIn.UserID,
+		Name:           detail.UserName,
+		InvitationID:   detail.CheckIn.InvitationID,
+		InvitationName: detail.InvitationName,
+		SessionID:      detail.SessionID,
+		SessionName:    detail.SessionName,
+		PeopleCount:    detail.CheckIn.PeopleCount,
+		Gate:           detail.CheckIn.Gate,
+		ScannedBy:      detail.CheckIn.ScannedBy,
+		CreatedAt:      detail.CheckIn.CreatedAt,
+	}
+}
+
+func (handler *dashboardHandler) attendanceSummary(ctx context.Context) (*AttendanceSummaryResponse, error) {
+	summary, err := handler.checkInStore.FindAttendanceSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	latestCheckIns, err := handler.checkInStore.FindAllLatest(ctx, latestCheckInLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AttendanceSummaryResponse{
+		Sessions:       newAttendanceCountItems(summary.Sessions),
+		Tags:           newAttendanceCountItems(summary.Tags),
+		LatestCheckIns: make([]event.CheckInData, len(latestCheckIns)),
+	}
+	for _, session := range resp.Sessions {
+		resp.Total.MaxSeats += session.MaxSeats
+		resp.Total.ExpectedPeople += session.ExpectedPeople
+		resp.Total.ArrivedPeople += session.ArrivedPeople
+		resp.Total.DeclinedUsers += session.DeclinedUsers
+		resp.Total.MaybeUserCount += session.MaybeUserCount
+		resp.Total.MaybePeopleCount += session.MaybePeopleCount
+		resp.Total.PendingUsers += session.PendingUsers
+	}
+	for idx, detail := range latestCheckIns {
+		resp.LatestCheckIns[idx] = newCheckInEventData(detail)
+	}
+
+	return resp, nil
+}
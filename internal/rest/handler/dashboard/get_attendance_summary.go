@@ -0,0 +1,23 @@
+package dashboard
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+func (handler *dashboardHandler) GetAttendanceSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	summary, err := handler.attendanceSummary(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, summary)
+}
@@ -0,0 +1,99 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+const (
+	summaryInterval   = 2 * time.Second
+	heartbeatInterval = 15 * time.Second
+
+	eventSummary = "summary"
+)
+
+func (handler *dashboardHandler) StreamAttendance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+
+	events, unsubscribe := handler.broker.Subscribe()
+	defer unsubscribe()
+
+	summary, err := handler.attendanceSummary(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, rc, eventSummary, summary); err != nil {
+		return
+	}
+
+	summaryTicker := time.NewTicker(summaryInterval)
+	defer summaryTicker.Stop()
+	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	summaryStale := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, rc, evt.Type, evt.Data); err != nil {
+				return
+			}
+			summaryStale = true
+		case <-summaryTicker.C:
+			if !summaryStale {
+				continue
+			}
+			summary, err := handler.attendanceSummary(ctx)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			if err := writeEvent(w, rc, eventSummary, summary); err != nil {
+				return
+			}
+			summaryStale = false
+		case <-heartbeatTicker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, rc *http.ResponseController, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
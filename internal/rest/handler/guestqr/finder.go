@@ -0,0 +1,81 @@
+package guestqr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/token"
+)
+
+type Finder struct {
+	userStore store.User
+	jwt       token.JWT
+}
+
+func NewFinder(userStore store.User, jwt token.JWT) *Finder {
+	return &Finder{
+		userStore: userStore,
+		jwt:       jwt,
+	}
+}
+
+func (f *Finder) Find(ctx context.Context, payload string, userID string) (*store.UserCheckInData, *apierror.Error) {
+	if payload != "" {
+		return f.Verify(ctx, payload)
+	}
+
+	guest, err := f.userStore.FindOneCheckInDataByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			notFoundErr := apierror.NotFoundError("Guest not found")
+			return nil, &notFoundErr
+		}
+		log.Println(err)
+		internalErr := apierror.InternalServerError()
+		return nil, &internalErr
+	}
+
+	if guest.Invitation.Status == store.InvitationStatusRevoked {
+		goneErr := apierror.GoneError("Invitation has been revoked").WithCode(apierror.CodeInvitationRevoked)
+		return nil, &goneErr
+	}
+
+	return guest, nil
+}
+
+func (f *Finder) Verify(ctx context.Context, payload string) (*store.UserCheckInData, *apierror.Error) {
+	invalidErr := apierror.BadRequestError("QR code is not a valid check-in code").WithCode(apierror.CodeCheckInInvalid)
+
+	claim, err := f.jwt.GetCheckInClaims(strings.TrimSpace(payload))
+	if err != nil {
+		log.Println(err)
+		return nil, &invalidErr
+	}
+
+	guest, err := f.userStore.FindOneCheckInDataByID(ctx, claim.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			notFoundErr := apierror.NotFoundError("Guest not found").WithCode(apierror.CodeCheckInInvalid)
+			return nil, &notFoundErr
+		}
+		log.Println(err)
+		internalErr := apierror.InternalServerError()
+		return nil, &internalErr
+	}
+
+	if guest.Invitation.ID != claim.InvitationID {
+		return nil, &invalidErr
+	}
+
+	if guest.Invitation.Status == store.InvitationStatusRevoked {
+		goneErr := apierror.GoneError("Invitation has been revoked").WithCode(apierror.CodeInvitationRevoked)
+		return nil, &goneErr
+	}
+
+	return guest, nil
+}
@@ -1,55 +1,162 @@
 package invitation
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	apierror "be-wedding/internal/rest/error"
 	"be-wedding/internal/store"
+	"be-wedding/pkg/whatsapp"
 
 	"be-wedding/internal/rest/response"
 )
 
 type InvitationResponse struct {
-	InvitationID string `json:"invitation_id"`
-	Name         string `json:"name"`
-	Session      int64  `json:"session"`
+	InvitationID   string     `json:"invitation_id"`
+	Code           string     `json:"code"`
+	Type           string     `json:"type"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	SessionID      string     `json:"session_id"`
+	Schedule       string     `json:"schedule"`
+	WhatsAppNumber string     `json:"wa_number,omitempty"`
+	MaxSeats       int64      `json:"max_seats"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	TableName      string     `json:"table_name,omitempty"`
 }
 
-type CreateInvitationRequest struct {
-	Type    string `json:"type"`
-	Name    string `json:"name"`
-	Session int64  `json:"session"`
+func newInvitationResponse(invitation *store.InvitationData) InvitationResponse {
+	return InvitationResponse{
+		InvitationID:   invitation.ID,
+		Code:           invitation.Code,
+		Type:           invitation.Type,
+		Name:           invitation.Name,
+		Status:         invitation.Status,
+		SessionID:      invitation.SessionID,
+		Schedule:       invitation.Schedule,
+		WhatsAppNumber: invitation.WhatsAppNumber,
+		MaxSeats:       invitation.MaxSeats,
+		ExpiresAt:      nullTimePtr(invitation.ExpiresAt),
+		TableName:      invitation.TableName,
+	}
+}
+
+type InvitationRequest struct {
+	Type           string `json:"type"`
+	Name           string `json:"name"`
+	SessionID      string `json:"session_id"`
+	WhatsAppNumber string `json:"wa_number"`
+	MaxSeats       *int64 `json:"max_seats"`
+	ExpiresAt      string `json:"expires_at"`
+	TableName      string `json:"table_name"`
+
+	expiresAt sql.NullTime
+}
+
+func (r *InvitationRequest) validate() *apierror.FieldError {
+	var err error
+	fieldErr := apierror.NewFieldError()
+
+	r.Type = strings.ToUpper(strings.TrimSpace(r.Type))
+	r.Name = strings.TrimSpace(r.Name)
+	r.TableName = strings.TrimSpace(r.TableName)
+
+	if r.Name == "" {
+		fieldErr = fieldErr.WithField("name", "name is required")
+	}
+
+	if r.Type != store.InvitationTypeSingle && r.Type != store.InvitationTypeGroup {
+		fieldErr = fieldErr.WithField("type", "type must be SINGLE or GROUP")
+	}
+
+	if r.MaxSeats != nil && *r.MaxSeats < 1 {
+		fieldErr = fieldErr.WithField("max_seats", "max_seats must be at least 1")
+	}
+
+	if r.WhatsAppNumber != "" {
+		r.WhatsAppNumber, err = whatsapp.NormalizeNumber(r.WhatsAppNumber)
+		if err != nil {
+			fieldErr = fieldErr.WithField("wa_number", err.Error())
+		}
+	}
+
+	if utf8.RuneCountInString(r.TableName) > store.InvitationTableNameMaxLength {
+		fieldErr = fieldErr.WithField("table_name", fmt.Sprintf("table_name must be at most %d characters", store.InvitationTableNameMaxLength))
+	}
+
+	r.expiresAt, err = parseNullTime(r.ExpiresAt)
+	if err != nil {
+		fieldErr = fieldErr.WithField("expires_at", "expires_at must be in RFC3339 format")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+func (r *InvitationRequest) toInvitationData(id string, session *store.InvitationSessionData) *store.InvitationData {
+	invitation := &store.InvitationData{
+		ID:             id,
+		Type:           r.Type,
+		Name:           r.Name,
+		SessionID:      session.ID,
+		Schedule:       session.Schedule,
+		WhatsAppNumber: r.WhatsAppNumber,
+		ExpiresAt:      r.expiresAt,
+		TableName:      r.TableName,
+	}
+	if r.MaxSeats != nil {
+		invitation.MaxSeats = *r.MaxSeats
+	}
+
+	return invitation
 }
 
 func (handler *invitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	req := CreateInvitationRequest{}
+	req := InvitationRequest{}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.Error(w, apierror.BadRequestError(err.Error()))
 		return
 	}
 
-	newInvitation := store.InvitationData{
-		Type:    req.Type,
-		Name:    req.Name,
-		Session: req.Session,
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
 	}
 
-	if err := handler.invitationStore.Insert(ctx, &newInvitation); err != nil {
-		log.Println("error insert new invitation data: %w", err)
+	session, err := handler.invitationSessionStore.FindOneByID(ctx, req.SessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.BadRequestError("Invitation session not found"))
+			return
+		}
+		log.Println("error find invitation session data: %w", err)
 		response.Error(w, apierror.InternalServerError())
 		return
 	}
 
-	resp := InvitationResponse{
-		InvitationID: newInvitation.ID,
-		Name:         newInvitation.Name,
-		Session:      newInvitation.Session,
+	newInvitation := req.toInvitationData("", session)
+	if newInvitation.MaxSeats == 0 {
+		newInvitation.MaxSeats = store.DefaultMaxSeats(newInvitation.Type)
+	}
+
+	if err := handler.invitationStore.Insert(ctx, newInvitation); err != nil {
+		log.Println("error insert new invitation data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
 	}
 
-	response.Respond(w, http.StatusCreated, resp)
+	response.Respond(w, http.StatusCreated, newInvitationResponse(newInvitation))
 }
@@ -0,0 +1,134 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+type fakeInvitationSessionStore struct {
+	store.InvitationSession
+}
+
+func (s *fakeInvitationSessionStore) FindOneByID(ctx context.Context, id string) (*store.InvitationSessionData, error) {
+	return &store.InvitationSessionData{ID: id, Schedule: "Sabtu, 1 Juni 2024"}, nil
+}
+
+func TestInvitationRequestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          InvitationRequest
+		wantErr      []string
+		wantMaxSeats int64
+	}{
+		{
+			name: "omitted seats",
+			req:  InvitationRequest{Type: "single", Name: " Budi "},
+		},
+		{
+			name:         "explicit seats are kept",
+			req:          InvitationRequest{Type: "GROUP", Name: "Keluarga Ani", MaxSeats: int64Ptr(4)},
+			wantMaxSeats: 4,
+		},
+		{
+			name:    "zero seats",
+			req:     InvitationRequest{Type: "GROUP", Name: "Keluarga Ani", MaxSeats: int64Ptr(0)},
+			wantErr: []string{"max_seats"},
+		},
+		{
+			name:    "negative seats",
+			req:     InvitationRequest{Type: "GROUP", Name: "Keluarga Ani", MaxSeats: int64Ptr(-1)},
+			wantErr: []string{"max_seats"},
+		},
+		{
+			name:    "missing name and unknown type",
+			req:     InvitationRequest{Type: "FAMILY"},
+			wantErr: []string{"name", "type"},
+		},
+		{
+			name:    "invalid expiry",
+			req:     InvitationRequest{Type: "SINGLE", Name: "Budi", ExpiresAt: "next week"},
+			wantErr: []string{"expires_at"},
+		},
+		{
+			name: "table name is counted in characters",
+			req:  InvitationRequest{Type: "SINGLE", Name: "Budi", TableName: strings.Repeat("é", store.InvitationTableNameMaxLength)},
+		},
+		{
+			name:    "table name too long",
+			req:     InvitationRequest{Type: "SINGLE", Name: "Budi", TableName: strings.Repeat("a", store.InvitationTableNameMaxLength+1)},
+			wantErr: []string{"table_name"},
+		},
+		{
+			name:    "invalid number",
+			req:     InvitationRequest{Type: "SINGLE", Name: "Budi", WhatsAppNumber: "call me"},
+			wantErr: []string{"wa_number"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) != 0 {
+				if fieldErr == nil {
+					t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+				}
+				names := fieldNames(fieldErr)
+				for _, field := range tt.wantErr {
+					if !names[field] {
+						t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+					}
+				}
+				return
+			}
+			if fieldErr != nil {
+				t.Fatalf("validate() = %v", fieldErr.Fields)
+			}
+			if got := tt.req.toInvitationData("", &store.InvitationSessionData{}).MaxSeats; got != tt.wantMaxSeats {
+				t.Errorf("MaxSeats = %d, want %d", got, tt.wantMaxSeats)
+			}
+		})
+	}
+}
+
+func TestCreateInvitation(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantMaxSeats int64
+	}{
+		{name: "single defaults to one seat", body: `{"type":"SINGLE","name":"Budi","session_id":"s1"}`, wantMaxSeats: 1},
+		{name: "group defaults to the group quota", body: `{"type":"GROUP","name":"Keluarga Ani","session_id":"s1"}`, wantMaxSeats: store.InvitationGroupDefaultMaxSeats},
+		{name: "explicit seats are kept", body: `{"type":"GROUP","name":"Keluarga Ani","session_id":"s1","max_seats":4}`, wantMaxSeats: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationStore := &fakeInvitationStore{}
+			handler := &invitationHandler{invitationStore: invitationStore, invitationSessionStore: &fakeInvitationSessionStore{}}
+
+			rec := httptest.NewRecorder()
+			handler.CreateInvitation(rec, httptest.NewRequest(http.MethodPost, "/invitations", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+			}
+			resp := InvitationResponse{}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if invitationStore.inserted.MaxSeats != tt.wantMaxSeats || resp.MaxSeats != tt.wantMaxSeats {
+				t.Errorf("MaxSeats = %d, response %d, want %d", invitationStore.inserted.MaxSeats, resp.MaxSeats, tt.wantMaxSeats)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package invitation
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *invitationHandler) DeleteInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	invitationID := chi.URLParam(r, "id")
+
+	cascade := false
+	if cascadeStr := r.URL.Query().Get("cascade"); cascadeStr != "" {
+		var err error
+		cascade, err = strconv.ParseBool(cascadeStr)
+		if err != nil {
+			response.FieldError(w, apierror.NewFieldError().WithField("cascade", "cascade must be a boolean"))
+			return
+		}
+	}
+
+	promoted, err := handler.invitationStore.Delete(ctx, invitationID, cascade)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+		case errors.Is(err, store.ErrInvitationInUse):
+			response.Error(w, apierror.ConflictError("Invitation already has registered users, pass cascade=true to delete them along with their comments and RSVPs"))
+		default:
+			log.Println("error delete invitation data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.RespondSuccess(w)
+}
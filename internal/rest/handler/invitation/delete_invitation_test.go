@@ -0,0 +1,83 @@
+package invitation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"be-wedding/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type fakeInvitationStore struct {
+	store.Invitation
+	deleteErr     error
+	deletedID     string
+	deleteCascade bool
+	inserted      *store.InvitationData
+	updateSeats   int64
+	current       store.InvitationData
+}
+
+func (s *fakeInvitationStore) Insert(ctx context.Context, invitation *store.InvitationData) error {
+	invitation.ID = "0b6e4c7e-6a5f-4d52-9b8e-1f2a3b4c5d6e"
+	invitation.Code = "ABC2345"
+	s.inserted = invitation
+	return nil
+}
+
+func (s *fakeInvitationStore) Update(ctx context.Context, invitation *store.InvitationData) ([]*store.UserRSVPWaitlistDetailData, error) {
+	s.updateSeats = invitation.MaxSeats
+	invitation.ID = s.current.ID
+	invitation.Code = s.current.Code
+	if invitation.MaxSeats == 0 {
+		invitation.MaxSeats = s.current.MaxSeats
+	}
+	return nil, nil
+}
+
+func (s *fakeInvitationStore) Delete(ctx context.Context, id string, cascade bool) ([]*store.UserRSVPWaitlistDetailData, error) {
+	s.deletedID = id
+	s.deleteCascade = cascade
+	return nil, s.deleteErr
+}
+
+func TestDeleteInvitation(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		deleteErr   error
+		wantStatus  int
+		wantCascade bool
+	}{
+		{name: "deleted", wantStatus: http.StatusOK},
+		{name: "cascade", query: "?cascade=true", wantStatus: http.StatusOK, wantCascade: true},
+		{name: "invalid cascade", query: "?cascade=maybe", wantStatus: http.StatusUnprocessableEntity},
+		{name: "not found", deleteErr: sql.ErrNoRows, wantStatus: http.StatusNotFound},
+		{name: "in use", deleteErr: store.ErrInvitationInUse, wantStatus: http.StatusConflict},
+		{name: "store failure", deleteErr: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationStore := &fakeInvitationStore{deleteErr: tt.deleteErr}
+			handler := &invitationHandler{invitationStore: invitationStore}
+
+			router := chi.NewRouter()
+			router.Delete("/invitations/{id}", handler.DeleteInvitation)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/invitations/ABC2345"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK && (invitationStore.deletedID != "ABC2345" || invitationStore.deleteCascade != tt.wantCascade) {
+				t.Errorf("Delete(%q, %v), want (ABC2345, %v)", invitationStore.deletedID, invitationStore.deleteCascade, tt.wantCascade)
+			}
+		})
+	}
+}
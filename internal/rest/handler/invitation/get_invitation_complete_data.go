@@ -1,11 +1,13 @@
 package invitation
 
 import (
-	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
 
 	"be-wedding/internal/rest/response"
 
@@ -13,25 +15,72 @@ import (
 )
 
 type InvidationData struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Status   string `json:"status"`
-	Schedule string `json:"schedule"`
+	ID                string     `json:"id"`
+	Code              string     `json:"code"`
+	Name              string     `json:"name"`
+	Type              string     `json:"type"`
+	Status            string     `json:"status"`
+	Schedule          string     `json:"schedule"`
+	MaxSeats          int64      `json:"max_seats"`
+	ExpiresAt         *time.Time `json:"expires_at"`
+	TableName         string     `json:"table_name,omitempty"`
+	RSVPUnlockedUntil *time.Time `json:"rsvp_unlocked_until"`
 }
 
 type UserData struct {
-	ID             string `json:"id,omitempty"`
-	Name           string `json:"name,omitempty"`
-	WhatsAppNumber string `json:"wa_number,omitempty"`
-	Status         string `json:"status,omitempty"`
-	QRImageLink    string `json:"qr_image_link,omitempty"`
-	PeopleCount    int64  `json:"people_count,omitempty"`
+	ID             string              `json:"id,omitempty"`
+	Name           string              `json:"name,omitempty"`
+	WhatsAppNumber string              `json:"wa_number,omitempty"`
+	Status         string              `json:"status,omitempty"`
+	QRImageLink    string              `json:"qr_image_link,omitempty"`
+	Attendance     string              `json:"attendance,omitempty"`
+	PeopleCount    int64               `json:"people_count,omitempty"`
+	Attendees      []response.Attendee `json:"attendees,omitempty"`
+}
+
+type TagData struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func newTagDataList(tagList []store.TagData) []TagData {
+	tags := make([]TagData, len(tagList))
+	for idx, tag := range tagList {
+		tags[idx] = TagData{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			Description: tag.Description,
+		}
+	}
+
+	return tags
 }
 
 type GetInvitationCompleteDataResponse struct {
 	Invitation InvidationData `json:"invitation"`
 	User       UserData       `json:"user,omitempty"`
+	Users      []UserData     `json:"users"`
+	Tags       []TagData      `json:"tags"`
+}
+
+func (handler *invitationHandler) newUserData(user store.InvitationUserData) UserData {
+	userData := UserData{
+		ID:             user.ID,
+		Name:           user.Name,
+		WhatsAppNumber: user.WhatsAppNumber,
+		Status:         user.Status,
+		Attendance:     user.Attendance,
+		PeopleCount:    user.PeopleCount,
+		Attendees:      response.NewAttendeeList(user.Attendees),
+	}
+	if user.QRImage != "" {
+		userData.QRImageLink = handler.blobStorage.URL(user.QRImage)
+	} else {
+		userData.QRImageLink = strings.TrimSuffix(handler.apiCfg.BaseURL, "/") + "/users/" + user.ID + "/qr.png"
+	}
+
+	return userData
 }
 
 func (handler *invitationHandler) GetInvitationCompleteData(w http.ResponseWriter, r *http.Request) {
@@ -45,22 +94,36 @@ func (handler *invitationHandler) GetInvitationCompleteData(w http.ResponseWrite
 		return
 	}
 
+	switch invitationCompleteData.Invitation.Status {
+	case store.InvitationStatusRevoked:
+		response.Error(w, apierror.GoneError("Invitation has been revoked, please contact the host").WithCode(apierror.CodeInvitationRevoked))
+		return
+	case store.InvitationStatusExpired:
+		response.Error(w, apierror.GoneError("Invitation has expired, please contact the host").WithCode(apierror.CodeInvitationExpired))
+		return
+	}
+
 	resp := GetInvitationCompleteDataResponse{
 		Invitation: InvidationData{
-			ID:       invitationCompleteData.Invitation.ID,
-			Name:     invitationCompleteData.Invitation.Name,
-			Type:     invitationCompleteData.Invitation.Type,
-			Status:   invitationCompleteData.Invitation.Status,
-			Schedule: invitationCompleteData.Invitation.Schedule,
-		},
-		User: UserData{
-			ID:             invitationCompleteData.User.ID,
-			Name:           invitationCompleteData.User.Name,
-			WhatsAppNumber: invitationCompleteData.User.WhatsAppNumber,
-			Status:         invitationCompleteData.User.Status,
-			QRImageLink:    fmt.Sprintf("http://localhost/static/%s", invitationCompleteData.User.QRImage),
-			PeopleCount:    invitationCompleteData.User.PeopleCount,
+			ID:                invitationCompleteData.Invitation.ID,
+			Code:              invitationCompleteData.Invitation.Code,
+			Name:              invitationCompleteData.Invitation.Name,
+			Type:              invitationCompleteData.Invitation.Type,
+			Status:            invitationCompleteData.Invitation.Status,
+			Schedule:          invitationCompleteData.Invitation.Schedule,
+			MaxSeats:          invitationCompleteData.Invitation.MaxSeats,
+			ExpiresAt:         nullTimePtr(invitationCompleteData.Invitation.ExpiresAt),
+			TableName:         invitationCompleteData.Invitation.TableName,
+			RSVPUnlockedUntil: nullTimePtr(invitationCompleteData.Invitation.RSVPUnlockedUntil),
 		},
+		Users: make([]UserData, len(invitationCompleteData.Users)),
+		Tags:  newTagDataList(invitationCompleteData.Tags),
+	}
+	for idx, user := range invitationCompleteData.Users {
+		resp.Users[idx] = handler.newUserData(user)
+	}
+	if len(resp.Users) != 0 {
+		resp.User = resp.Users[0]
 	}
 
 	response.Respond(w, http.StatusOK, resp)
@@ -0,0 +1,206 @@
+package invitation
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+type GetInvitationListRequest struct {
+	status    string
+	invType   string
+	sessionID string
+	name      string
+	tagID     string
+	sortStr   string
+	pageStr   string
+	limitStr  string
+	page      int
+	limit     int
+	sortBy    string
+	sortDesc  bool
+}
+
+func (r *GetInvitationListRequest) validate() *apierror.FieldError {
+	var err error
+	fieldErr := apierror.NewFieldError()
+
+	r.status = strings.ToUpper(strings.TrimSpace(r.status))
+	r.invType = strings.ToUpper(strings.TrimSpace(r.invType))
+	r.sessionID = strings.TrimSpace(r.sessionID)
+	r.name = strings.TrimSpace(r.name)
+	r.tagID = strings.TrimSpace(r.tagID)
+	r.sortStr = strings.TrimSpace(r.sortStr)
+	r.pageStr = strings.TrimSpace(r.pageStr)
+	r.limitStr = strings.TrimSpace(r.limitStr)
+
+	if r.pageStr == "" {
+		r.pageStr = "1"
+	}
+	if r.limitStr == "" {
+		r.limitStr = "50"
+	}
+
+	r.page, err = strconv.Atoi(r.pageStr)
+	if err != nil || r.page < 1 {
+		fieldErr = fieldErr.WithField("page", "page must be a positive integer")
+	}
+
+	r.limit, err = strconv.Atoi(r.limitStr)
+	if err != nil || r.limit < 1 {
+		fieldErr = fieldErr.WithField("limit", "limit must be a positive integer")
+	}
+
+	switch r.status {
+	case "", store.InvitationStatusAvailable, store.InvitationStatusUsed, store.InvitationStatusRevoked, store.InvitationStatusExpired:
+	default:
+		fieldErr = fieldErr.WithField("status", "status must be AVAILABLE, USED, REVOKED or EXPIRED")
+	}
+
+	if r.invType != "" && r.invType != store.InvitationTypeSingle && r.invType != store.InvitationTypeGroup {
+		fieldErr = fieldErr.WithField("type", "type must be SINGLE or GROUP")
+	}
+
+	if r.sortStr == "" {
+		r.sortStr = "-" + store.InvitationSortByCreatedAt
+	}
+	r.sortDesc = strings.HasPrefix(r.sortStr, "-")
+	r.sortBy = strings.TrimPrefix(r.sortStr, "-")
+	switch r.sortBy {
+	case store.InvitationSortByName, store.InvitationSortByCreatedAt, store.InvitationSortByStatus:
+	default:
+		fieldErr = fieldErr.WithField("sort", "sort must be one of name, created_at or status, prefixed with - for descending order")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+type GetInvitationListResponse struct {
+	TotalItems int                  `json:"total_items"`
+	TotalPages int                  `json:"total_pages"`
+	Items      []InvitationListItem `json:"items"`
+}
+
+type InvitationListItem struct {
+	ID                string     `json:"id"`
+	Code              string     `json:"code"`
+	Name              string     `json:"name"`
+	Type              string     `json:"type"`
+	Status            string     `json:"status"`
+	SessionID         string     `json:"session_id"`
+	Schedule          string     `json:"schedule"`
+	WhatsAppNumber    string     `json:"wa_number,omitempty"`
+	MaxSeats          int64      `json:"max_seats"`
+	ExpiresAt         *time.Time `json:"expires_at"`
+	TableName         string     `json:"table_name,omitempty"`
+	Users             []UserData `json:"users"`
+	Tags              []TagData  `json:"tags"`
+	RSVPUserCount     int64      `json:"rsvp_user_count"`
+	RSVPPeopleCount   int64      `json:"rsvp_people_count"`
+	DeclinedUserCount int64      `json:"declined_user_count"`
+	PendingUserCount  int64      `json:"pending_user_count"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func (handler *invitationHandler) GetInvitationList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := GetInvitationListRequest{
+		status:    r.URL.Query().Get("status"),
+		invType:   r.URL.Query().Get("type"),
+		sessionID: r.URL.Query().Get("session_id"),
+		name:      r.URL.Query().Get("q"),
+		tagID:     r.URL.Query().Get("tag_id"),
+		sortStr:   r.URL.Query().Get("sort"),
+		pageStr:   r.URL.Query().Get("page"),
+		limitStr:  r.URL.Query().Get("limit"),
+	}
+
+	fieldErr := req.validate()
+	if fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	filter := store.InvitationFilter{
+		Status:    req.status,
+		Type:      req.invType,
+		SessionID: req.sessionID,
+		Name:      req.name,
+		TagID:     req.tagID,
+		SortBy:    req.sortBy,
+		SortDesc:  req.sortDesc,
+		Offset:    req.limit * (req.page - 1),
+		Limit:     req.limit,
+	}
+
+	totalItems, err := handler.invitationStore.Count(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	invitationList, err := handler.invitationStore.FindAll(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]InvitationListItem, len(invitationList))
+	for idx, item := range invitationList {
+		users := make([]UserData, len(item.Users))
+		for userIdx, user := range item.Users {
+			users[userIdx] = UserData{
+				ID:             user.ID,
+				Name:           user.Name,
+				WhatsAppNumber: user.WhatsAppNumber,
+				Status:         user.Status,
+				Attendance:     user.Attendance,
+				PeopleCount:    user.PeopleCount,
+			}
+		}
+
+		items[idx] = InvitationListItem{
+			ID:                item.Invitation.ID,
+			Code:              item.Invitation.Code,
+			Name:              item.Invitation.Name,
+			Type:              item.Invitation.Type,
+			Status:            item.Invitation.Status,
+			SessionID:         item.Invitation.SessionID,
+			Schedule:          item.Invitation.Schedule,
+			WhatsAppNumber:    item.Invitation.WhatsAppNumber,
+			MaxSeats:          item.Invitation.MaxSeats,
+			ExpiresAt:         nullTimePtr(item.Invitation.ExpiresAt),
+			TableName:         item.Invitation.TableName,
+			Users:             users,
+			Tags:              newTagDataList(item.Tags),
+			RSVPUserCount:     item.RSVPUserCount,
+			RSVPPeopleCount:   item.RSVPPeopleCount,
+			DeclinedUserCount: item.DeclinedUserCount,
+			PendingUserCount:  int64(len(item.Users)) - item.RSVPUserCount,
+			CreatedAt:         item.Invitation.CreatedAt,
+		}
+	}
+
+	resp := GetInvitationListResponse{
+		TotalItems: totalItems,
+		TotalPages: int(math.Ceil(float64(totalItems) / float64(req.limit))),
+		Items:      items,
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
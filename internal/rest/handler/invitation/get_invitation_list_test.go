@@ -0,0 +1,74 @@
+package invitation
+
+import (
+	"testing"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+)
+
+func fieldNames(fieldErr *apierror.FieldError) map[string]bool {
+	names := map[string]bool{}
+	for _, field := range fieldErr.Fields {
+		names[field.Name] = true
+	}
+
+	return names
+}
+
+func TestGetInvitationListRequestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       GetInvitationListRequest
+		wantErr   []string
+		wantPage  int
+		wantLimit int
+		wantSort  string
+		wantDesc  bool
+	}{
+		{
+			name:      "defaults",
+			req:       GetInvitationListRequest{},
+			wantPage:  1,
+			wantLimit: 50,
+			wantSort:  store.InvitationSortByCreatedAt,
+			wantDesc:  true,
+		},
+		{
+			name:      "lowercase filters and ascending sort",
+			req:       GetInvitationListRequest{status: " used ", invType: "group", sortStr: "name", pageStr: "3", limitStr: "20"},
+			wantPage:  3,
+			wantLimit: 20,
+			wantSort:  store.InvitationSortByName,
+		},
+		{
+			name:    "invalid values",
+			req:     GetInvitationListRequest{status: "GONE", invType: "FAMILY", sortStr: "-phone", pageStr: "0", limitStr: "ten"},
+			wantErr: []string{"page", "limit", "status", "type", "sort"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) != 0 {
+				if fieldErr == nil {
+					t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+				}
+				names := fieldNames(fieldErr)
+				for _, field := range tt.wantErr {
+					if !names[field] {
+						t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+					}
+				}
+				return
+			}
+			if fieldErr != nil {
+				t.Fatalf("validate() = %v", fieldErr.Fields)
+			}
+			if tt.req.page != tt.wantPage || tt.req.limit != tt.wantLimit || tt.req.sortBy != tt.wantSort || tt.req.sortDesc != tt.wantDesc {
+				t.Errorf("validate() page=%d limit=%d sort=%s desc=%v", tt.req.page, tt.req.limit, tt.req.sortBy, tt.req.sortDesc)
+			}
+		})
+	}
+}
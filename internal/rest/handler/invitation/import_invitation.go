@@ -0,0 +1,125 @@
+package invitation
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"be-wedding/internal/importer"
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+const maxImportFileSize = 5 << 20
+
+type ImportInvitationResponse struct {
+	DryRun      bool                 `json:"dry_run"`
+	AutoAssign  bool                 `json:"auto_assign"`
+	TotalRows   int                  `json:"total_rows"`
+	ValidRows   int                  `json:"valid_rows"`
+	Invitations []ImportedInvitation `json:"invitations"`
+	Errors      []importer.RowError  `json:"errors"`
+}
+
+type ImportedInvitation struct {
+	Line           int    `json:"line"`
+	InvitationID   string `json:"invitation_id,omitempty"`
+	Code           string `json:"code,omitempty"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	SessionID      string `json:"session_id"`
+	Schedule       string `json:"schedule"`
+	WhatsAppNumber string `json:"wa_number,omitempty"`
+	MaxSeats       int64  `json:"max_seats"`
+	TableName      string `json:"table_name,omitempty"`
+}
+
+func (handler *invitationHandler) ImportInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+
+	opts := importer.ImportOptions{}
+	fieldErr := apierror.NewFieldError()
+	boolParams := []struct {
+		name  string
+		value *bool
+	}{
+		{name: "dry_run", value: &opts.DryRun},
+		{name: "auto_assign", value: &opts.AutoAssign},
+	}
+	for _, param := range boolParams {
+		valueStr := r.URL.Query().Get(param.name)
+		if valueStr == "" {
+			continue
+		}
+		var err error
+		*param.value, err = strconv.ParseBool(valueStr)
+		if err != nil {
+			fieldErr = fieldErr.WithField(param.name, param.name+" must be a boolean")
+		}
+	}
+	if len(fieldErr.Fields) != 0 {
+		response.FieldError(w, fieldErr)
+		return
+	}
+
+	var csvFile io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			response.Error(w, apierror.BadRequestError("file is required"))
+			return
+		}
+		defer file.Close()
+		csvFile = file
+	}
+
+	result, err := handler.invitationImporter.Import(ctx, csvFile, opts)
+	if err != nil {
+		var fileErr *importer.FileError
+		if errors.As(err, &fileErr) {
+			response.Error(w, apierror.BadRequestError(fileErr.Error()))
+			return
+		}
+		log.Println("error import invitation data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp := ImportInvitationResponse{
+		DryRun:      opts.DryRun,
+		AutoAssign:  opts.AutoAssign,
+		TotalRows:   result.TotalRows,
+		ValidRows:   len(result.Rows),
+		Invitations: make([]ImportedInvitation, len(result.Rows)),
+		Errors:      result.Errors,
+	}
+	if resp.Errors == nil {
+		resp.Errors = []importer.RowError{}
+	}
+	for idx, row := range result.Rows {
+		resp.Invitations[idx] = ImportedInvitation{
+			Line:           row.Line,
+			InvitationID:   row.Invitation.ID,
+			Code:           row.Invitation.Code,
+			Name:           row.Invitation.Name,
+			Type:           row.Invitation.Type,
+			SessionID:      row.Invitation.SessionID,
+			Schedule:       row.Invitation.Schedule,
+			WhatsAppNumber: row.Invitation.WhatsAppNumber,
+			MaxSeats:       row.Invitation.MaxSeats,
+			TableName:      row.Invitation.TableName,
+		}
+	}
+
+	statusCode := http.StatusCreated
+	if opts.DryRun || resp.ValidRows == 0 {
+		statusCode = http.StatusOK
+	}
+
+	response.Respond(w, statusCode, resp)
+}
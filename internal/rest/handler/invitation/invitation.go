@@ -3,26 +3,67 @@ package invitation
 import (
 	"database/sql"
 	"net/http"
+	"time"
 
 	"be-wedding/internal/config"
+	"be-wedding/internal/importer"
 	"be-wedding/internal/store"
+	"be-wedding/internal/waitlist"
+	"be-wedding/pkg/storage"
 )
 
 type InvitationHandler interface {
 	CreateInvitation(w http.ResponseWriter, r *http.Request)
+	UpdateInvitation(w http.ResponseWriter, r *http.Request)
+	DeleteInvitation(w http.ResponseWriter, r *http.Request)
 	GetInvitationCompleteData(w http.ResponseWriter, r *http.Request)
+	GetInvitationList(w http.ResponseWriter, r *http.Request)
+	ImportInvitation(w http.ResponseWriter, r *http.Request)
+	RevokeInvitation(w http.ResponseWriter, r *http.Request)
+	ReinstateInvitation(w http.ResponseWriter, r *http.Request)
+	UnlockInvitationRSVP(w http.ResponseWriter, r *http.Request)
+	LockInvitationRSVP(w http.ResponseWriter, r *http.Request)
 }
 
 type invitationHandler struct {
-	apiCfg          config.API
-	db              *sql.DB
-	invitationStore store.Invitation
+	apiCfg                 config.API
+	db                     *sql.DB
+	invitationStore        store.Invitation
+	invitationSessionStore store.InvitationSession
+	invitationImporter     *importer.InvitationImporter
+	blobStorage            storage.Storage
+	waitlistNotifier       *waitlist.Notifier
 }
 
-func NewInvitationHandler(apiCfg config.API, db *sql.DB, invitationStore store.Invitation) InvitationHandler {
+func NewInvitationHandler(apiCfg config.API, db *sql.DB, invitationStore store.Invitation, invitationSessionStore store.InvitationSession, blobStorage storage.Storage, waitlistNotifier *waitlist.Notifier) InvitationHandler {
 	return &invitationHandler{
-		apiCfg:          apiCfg,
-		db:              db,
-		invitationStore: invitationStore,
+		apiCfg:                 apiCfg,
+		db:                     db,
+		invitationStore:        invitationStore,
+		invitationSessionStore: invitationSessionStore,
+		invitationImporter:     importer.NewInvitationImporter(invitationStore, invitationSessionStore),
+		blobStorage:            blobStorage,
+		waitlistNotifier:       waitlistNotifier,
 	}
 }
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+func parseNullTime(value string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: t.UTC(), Valid: true}, nil
+}
@@ -0,0 +1,40 @@
+package invitation
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestParseNullTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    sql.NullTime
+		wantErr bool
+	}{
+		{value: "", want: sql.NullTime{}},
+		{value: "2024-05-01T10:00:00+07:00", want: sql.NullTime{Time: time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC), Valid: true}},
+		{value: "2024-05-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseNullTime(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseNullTime(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got.Valid != tt.want.Valid || !got.Time.Equal(tt.want.Time) || got.Time.Location() != time.UTC {
+			t.Errorf("parseNullTime(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNullTimePtr(t *testing.T) {
+	if nullTimePtr(sql.NullTime{}) != nil {
+		t.Error("nullTimePtr(NULL) != nil")
+	}
+	now := time.Now()
+	if got := nullTimePtr(sql.NullTime{Time: now, Valid: true}); got == nil || !got.Equal(now) {
+		t.Errorf("nullTimePtr() = %v, want %v", got, now)
+	}
+}
@@ -0,0 +1,76 @@
+package invitation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReinstateInvitationRequest struct {
+	ExpiresAt string `json:"expires_at"`
+}
+
+type ReinstateInvitationResponse struct {
+	InvitationID string     `json:"invitation_id"`
+	Status       string     `json:"status"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+}
+
+func (handler *invitationHandler) ReinstateInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	invitationID := chi.URLParam(r, "id")
+
+	req := ReinstateInvitationRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	expiresAt, err := parseNullTime(req.ExpiresAt)
+	if err != nil {
+		response.FieldError(w, apierror.NewFieldError().WithField("expires_at", "expires_at must be in RFC3339 format"))
+		return
+	}
+	if expiresAt.Valid && !expiresAt.Time.After(time.Now()) {
+		response.FieldError(w, apierror.NewFieldError().WithField("expires_at", "expires_at must be in the future"))
+		return
+	}
+
+	invitation := &store.InvitationData{
+		ID:        invitationID,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := handler.invitationStore.Reinstate(ctx, invitation); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+		case errors.Is(err, store.ErrInvitationSessionFull):
+			response.Error(w, apierror.ConflictError("Invitation session has no seats left for the invitation"))
+		default:
+			log.Println("error reinstate invitation: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	resp := ReinstateInvitationResponse{
+		InvitationID: invitation.ID,
+		Status:       invitation.Status,
+		ExpiresAt:    nullTimePtr(invitation.ExpiresAt),
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
@@ -0,0 +1,33 @@
+package invitation
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *invitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	invitationID := chi.URLParam(r, "id")
+
+	promoted, err := handler.invitationStore.Revoke(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+			return
+		}
+		log.Println("error revoke invitation: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.RespondSuccess(w)
+}
@@ -0,0 +1,80 @@
+package invitation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type UnlockInvitationRSVPRequest struct {
+	Until string `json:"until"`
+}
+
+type UnlockInvitationRSVPResponse struct {
+	InvitationID      string     `json:"invitation_id"`
+	RSVPUnlockedUntil *time.Time `json:"rsvp_unlocked_until"`
+}
+
+func (handler *invitationHandler) UnlockInvitationRSVP(w http.ResponseWriter, r *http.Request) {
+	req := UnlockInvitationRSVPRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	until, err := parseNullTime(req.Until)
+	if err != nil {
+		response.FieldError(w, apierror.NewFieldError().WithField("until", "until must be in RFC3339 format"))
+		return
+	}
+	if !until.Valid {
+		response.FieldError(w, apierror.NewFieldError().WithField("until", "until is required"))
+		return
+	}
+	if !until.Time.After(time.Now()) {
+		response.FieldError(w, apierror.NewFieldError().WithField("until", "until must be in the future"))
+		return
+	}
+	until.Time = until.Time.UTC()
+
+	handler.saveRSVPUnlock(w, r, until)
+}
+
+func (handler *invitationHandler) LockInvitationRSVP(w http.ResponseWriter, r *http.Request) {
+	handler.saveRSVPUnlock(w, r, sql.NullTime{})
+}
+
+func (handler *invitationHandler) saveRSVPUnlock(w http.ResponseWriter, r *http.Request, until sql.NullTime) {
+	invitation := &store.InvitationData{
+		ID:                chi.URLParam(r, "id"),
+		RSVPUnlockedUntil: until,
+	}
+
+	if err := handler.invitationStore.UnlockRSVP(r.Context(), invitation); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+			return
+		}
+		log.Println("error unlock invitation rsvp: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp := UnlockInvitationRSVPResponse{
+		InvitationID:      invitation.ID,
+		RSVPUnlockedUntil: nullTimePtr(invitation.RSVPUnlockedUntil),
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
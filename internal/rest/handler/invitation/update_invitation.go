@@ -0,0 +1,68 @@
+package invitation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *invitationHandler) UpdateInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	invitationID := chi.URLParam(r, "id")
+
+	req := InvitationRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	session, err := handler.invitationSessionStore.FindOneByID(ctx, req.SessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.BadRequestError("Invitation session not found"))
+			return
+		}
+		log.Println("error find invitation session data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	invitation := req.toInvitationData(invitationID, session)
+
+	promoted, err := handler.invitationStore.Update(ctx, invitation)
+	if err != nil {
+		var seatQuotaErr *store.SeatQuotaError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+		case errors.As(err, &seatQuotaErr):
+			response.FieldError(w, apierror.NewFieldError().WithField("max_seats",
+				fmt.Sprintf("max_seats cannot be lower than the %d seats already taken", seatQuotaErr.ReservedSeats)))
+		case errors.Is(err, store.ErrInvitationSessionFull):
+			response.Error(w, apierror.ConflictError("Invitation session has no seats left for the invitation"))
+		default:
+			log.Println("error update invitation data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.Respond(w, http.StatusOK, newInvitationResponse(invitation))
+}
@@ -0,0 +1,56 @@
+package invitation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"be-wedding/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestUpdateInvitation(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStoreSeats int64
+		wantMaxSeats   int64
+	}{
+		{name: "omitted seats keep the current quota", body: `{"type":"GROUP","name":"Keluarga Ani","session_id":"s1"}`, wantMaxSeats: 6},
+		{name: "explicit seats", body: `{"type":"GROUP","name":"Keluarga Ani","session_id":"s1","max_seats":4}`, wantStoreSeats: 4, wantMaxSeats: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationStore := &fakeInvitationStore{
+				current: store.InvitationData{ID: "0b6e4c7e-6a5f-4d52-9b8e-1f2a3b4c5d6e", Code: "ABC2345", MaxSeats: 6},
+			}
+			handler := &invitationHandler{invitationStore: invitationStore, invitationSessionStore: &fakeInvitationSessionStore{}}
+
+			router := chi.NewRouter()
+			router.Put("/invitations/{id}", handler.UpdateInvitation)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/invitations/abc2345", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			resp := InvitationResponse{}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Code != "ABC2345" || resp.InvitationID != invitationStore.current.ID {
+				t.Errorf("response invitation = (%q, %q), want (%q, ABC2345)", resp.InvitationID, resp.Code, invitationStore.current.ID)
+			}
+			if invitationStore.updateSeats != tt.wantStoreSeats {
+				t.Errorf("Update() max seats = %d, want %d", invitationStore.updateSeats, tt.wantStoreSeats)
+			}
+			if resp.MaxSeats != tt.wantMaxSeats {
+				t.Errorf("response max_seats = %d, want %d", resp.MaxSeats, tt.wantMaxSeats)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package pass
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"be-wedding/internal/pass"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *passHandler) GetInvitationPass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	invitationID := chi.URLParam(r, "id")
+
+	invitation, err := handler.invitationStore.FindOneByID(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+			return
+		}
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	if invitation.Status == store.InvitationStatusRevoked {
+		response.Error(w, apierror.GoneError("Invitation has been revoked, please contact the host").WithCode(apierror.CodeInvitationRevoked))
+		return
+	}
+
+	guestList, err := handler.userStore.FindAllCheckInData(ctx, store.UserCheckInFilter{InvitationID: invitation.ID})
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	guestList = attendingGuests(guestList)
+	if len(guestList) == 0 {
+		response.Error(w, apierror.NotFoundError("Invitation has no attending guests yet"))
+		return
+	}
+
+	handler.respondPDF(w, "pass-"+invitation.Code+".pdf", guestList, pass.Passes)
+}
@@ -0,0 +1,59 @@
+package pass
+
+import (
+	"log"
+	"net/http"
+
+	"be-wedding/internal/pass"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+type GetPassSheetRequest struct {
+	tagID     string
+	sessionID string
+}
+
+func (r GetPassSheetRequest) validate() *apierror.FieldError {
+	if r.tagID == "" && r.sessionID == "" {
+		fieldErr := apierror.NewFieldError().
+			WithField("tag_id", "tag_id or session_id is required").
+			WithField("session_id", "tag_id or session_id is required")
+		return &fieldErr
+	}
+
+	return nil
+}
+
+func (handler *passHandler) GetPassSheet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := GetPassSheetRequest{
+		tagID:     r.URL.Query().Get("tag_id"),
+		sessionID: r.URL.Query().Get("session_id"),
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	guestList, err := handler.userStore.FindAllCheckInData(ctx, store.UserCheckInFilter{
+		SessionID: req.sessionID,
+		TagID:     req.tagID,
+	})
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	guestList = attendingGuests(guestList)
+	if len(guestList) == 0 {
+		response.Error(w, apierror.NotFoundError("No attending guests match the given tag or session"))
+		return
+	}
+
+	handler.respondPDF(w, "passes.pdf", guestList, pass.Sheet)
+}
@@ -0,0 +1,43 @@
+package pass
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"be-wedding/internal/pass"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *passHandler) GetUserPass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	guest, err := handler.userStore.FindOneCheckInDataByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("User id not found"))
+			return
+		}
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	if guest.Invitation.Status == store.InvitationStatusRevoked {
+		response.Error(w, apierror.GoneError("Invitation has been revoked, please contact the host").WithCode(apierror.CodeInvitationRevoked))
+		return
+	}
+	if guest.Declined() {
+		response.Error(w, apierror.ConflictError("Guest has declined the invitation, no pass is issued"))
+		return
+	}
+
+	handler.respondPDF(w, "pass-"+guest.User.ID+".pdf", []*store.UserCheckInData{guest}, pass.Passes)
+}
@@ -0,0 +1,117 @@
+package pass
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/pass"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/qr"
+	"be-wedding/pkg/token"
+
+	"be-wedding/internal/rest/response"
+)
+
+type PassHandler interface {
+	GetUserPass(w http.ResponseWriter, r *http.Request)
+	GetInvitationPass(w http.ResponseWriter, r *http.Request)
+	GetPassSheet(w http.ResponseWriter, r *http.Request)
+}
+
+type passHandler struct {
+	apiCfg          config.API
+	db              *sql.DB
+	userStore       store.User
+	invitationStore store.Invitation
+	jwt             token.JWT
+	qrRenderer      *qr.Renderer
+}
+
+func NewPassHandler(apiCfg config.API, db *sql.DB, userStore store.User, invitationStore store.Invitation, jwt token.JWT, qrRenderer *qr.Renderer) PassHandler {
+	return &passHandler{
+		apiCfg:          apiCfg,
+		db:              db,
+		userStore:       userStore,
+		invitationStore: invitationStore,
+		jwt:             jwt,
+		qrRenderer:      qrRenderer,
+	}
+}
+
+const passQRSize = 512
+
+const passDateLayout = "Monday, 2 January 2006"
+
+func (handler *passHandler) newGuest(guest *store.UserCheckInData) (pass.Guest, error) {
+	checkInToken, err := handler.jwt.CreateCheckInToken(token.CheckInClaim{
+		UserID:       guest.User.ID,
+		InvitationID: guest.Invitation.ID,
+	})
+	if err != nil {
+		return pass.Guest{}, fmt.Errorf("failed to create check-in token: %w", err)
+	}
+
+	qrImage, err := handler.qrRenderer.Image(checkInToken, passQRSize)
+	if err != nil {
+		return pass.Guest{}, fmt.Errorf("failed to render qr image: %w", err)
+	}
+
+	schedule := guest.Session.Schedule
+	if guest.Session.StartTime.Valid {
+		schedule = guest.Session.StartTime.Time.Format(passDateLayout) + ", " + schedule
+	}
+
+	return pass.Guest{
+		Name:           guest.User.Name,
+		InvitationCode: guest.Invitation.Code,
+		SessionName:    guest.Session.Name,
+		Schedule:       schedule,
+		Venue:          guest.Session.Venue,
+		TableName:      guest.Invitation.TableName,
+		People:         guest.AllowedPeople(),
+		QR:             qrImage,
+	}, nil
+}
+
+func attendingGuests(guestList []*store.UserCheckInData) []*store.UserCheckInData {
+	attending := make([]*store.UserCheckInData, 0, len(guestList))
+	for _, guest := range guestList {
+		if !guest.Declined() {
+			attending = append(attending, guest)
+		}
+	}
+
+	return attending
+}
+
+func (handler *passHandler) respondPDF(w http.ResponseWriter, fileName string, guestList []*store.UserCheckInData, layout func([]pass.Guest) ([]byte, error)) {
+	guests := make([]pass.Guest, len(guestList))
+	for idx, guest := range guestList {
+		var err error
+		guests[idx], err = handler.newGuest(guest)
+		if err != nil {
+			log.Println("error create guest pass: %w", err)
+			response.Error(w, apierror.InternalServerError())
+			return
+		}
+	}
+
+	document, err := layout(guests)
+	if err != nil {
+		log.Println("error render guest pass pdf: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="`+fileName+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
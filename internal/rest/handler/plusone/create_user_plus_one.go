@@ -0,0 +1,96 @@
+package plusone
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CreateUserPlusOneRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *CreateUserPlusOneRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		fieldErr = fieldErr.WithField("name", "name is required")
+	}
+	if utf8.RuneCountInString(r.Name) > store.AttendeeNameMaxLength {
+		fieldErr = fieldErr.WithField("name", fmt.Sprintf("name must be at most %d characters", store.AttendeeNameMaxLength))
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+type CreateUserPlusOneResponse struct {
+	ID           string `json:"id"`
+	InvitationID string `json:"invitation_id"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+}
+
+func (handler *plusOneHandler) CreateUserPlusOne(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	req := CreateUserPlusOneRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	plusOneRequest := &store.PlusOneRequestData{
+		UserID: userID,
+		Name:   req.Name,
+	}
+	if err := handler.plusOneStore.Insert(ctx, plusOneRequest); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("User id not found"))
+			return
+		}
+		if errors.Is(err, store.ErrPlusOneNotAllowed) {
+			response.Error(w, apierror.ForbiddenError("Plus-one requests are only open to a single invitation without a plus-one yet"))
+			return
+		}
+		if errors.Is(err, store.ErrPlusOnePending) {
+			response.Error(w, apierror.ConflictError("User already has a plus-one request waiting for a decision"))
+			return
+		}
+		log.Println("error create plus-one request: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp := CreateUserPlusOneResponse{
+		ID:           plusOneRequest.ID,
+		InvitationID: plusOneRequest.InvitationID,
+		Name:         plusOneRequest.Name,
+		Status:       plusOneRequest.Status,
+	}
+
+	response.Respond(w, http.StatusCreated, resp)
+}
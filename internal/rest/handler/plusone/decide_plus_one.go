@@ -0,0 +1,74 @@
+package plusone
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type DecidePlusOneRequest struct {
+	DecidedBy string `json:"decided_by"`
+	Note      string `json:"note"`
+}
+
+func (handler *plusOneHandler) ApprovePlusOne(w http.ResponseWriter, r *http.Request) {
+	handler.decidePlusOne(w, r, store.PlusOneStatusApproved)
+}
+
+func (handler *plusOneHandler) RejectPlusOne(w http.ResponseWriter, r *http.Request) {
+	handler.decidePlusOne(w, r, store.PlusOneStatusRejected)
+}
+
+func (handler *plusOneHandler) decidePlusOne(w http.ResponseWriter, r *http.Request, status string) {
+	ctx := r.Context()
+
+	req := DecidePlusOneRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	detail, promoted, err := handler.plusOneStore.Decide(ctx, &store.PlusOneRequestData{
+		ID:           chi.URLParam(r, "id"),
+		Status:       status,
+		DecidedBy:    strings.TrimSpace(req.DecidedBy),
+		DecisionNote: strings.TrimSpace(req.Note),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Plus-one request id not found"))
+			return
+		}
+		if errors.Is(err, store.ErrPlusOneDecided) {
+			response.Error(w, apierror.ConflictError("Plus-one request has already been decided"))
+			return
+		}
+		if errors.Is(err, store.ErrPlusOneNotAllowed) {
+			response.Error(w, apierror.GoneError("Invitation has been revoked").WithCode(apierror.CodeInvitationRevoked))
+			return
+		}
+		if errors.Is(err, store.ErrPlusOneNoSeat) {
+			response.Error(w, apierror.ConflictError("Session has no seat left for a plus-one"))
+			return
+		}
+		log.Println("error decide plus-one request: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	handler.notifyDecision(detail)
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.Respond(w, http.StatusOK, newPlusOneItem(detail))
+}
@@ -0,0 +1,51 @@
+package plusone
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *plusOneHandler) GetPlusOneList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := store.PlusOneRequestFilter{
+		UserID: r.URL.Query().Get("user_id"),
+		Status: strings.ToUpper(r.URL.Query().Get("status")),
+	}
+	switch filter.Status {
+	case "", store.PlusOneStatusPending, store.PlusOneStatusApproved, store.PlusOneStatusRejected:
+	default:
+		response.Error(w, apierror.BadRequestError("status must be PENDING, APPROVED or REJECTED"))
+		return
+	}
+
+	requestList, err := handler.plusOneStore.FindAll(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newGetPlusOneListResponse(requestList))
+}
+
+func (handler *plusOneHandler) GetUserPlusOneList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestList, err := handler.plusOneStore.FindAll(ctx, store.PlusOneRequestFilter{UserID: chi.URLParam(r, "id")})
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newGetPlusOneListResponse(requestList))
+}
@@ -0,0 +1,125 @@
+package plusone
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/store"
+	"be-wedding/internal/waitlist"
+	"be-wedding/pkg/whatsapp"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+)
+
+type PlusOneHandler interface {
+	CreateUserPlusOne(w http.ResponseWriter, r *http.Request)
+	GetUserPlusOneList(w http.ResponseWriter, r *http.Request)
+	GetPlusOneList(w http.ResponseWriter, r *http.Request)
+	ApprovePlusOne(w http.ResponseWriter, r *http.Request)
+	RejectPlusOne(w http.ResponseWriter, r *http.Request)
+}
+
+type plusOneHandler struct {
+	apiCfg           config.API
+	db               *sql.DB
+	plusOneStore     store.PlusOne
+	whatsAppClient   whatsapp.Client
+	waitlistNotifier *waitlist.Notifier
+}
+
+func NewPlusOneHandler(apiCfg config.API, db *sql.DB, plusOneStore store.PlusOne, whatsAppClient whatsapp.Client, waitlistNotifier *waitlist.Notifier) PlusOneHandler {
+	return &plusOneHandler{
+		apiCfg:           apiCfg,
+		db:               db,
+		plusOneStore:     plusOneStore,
+		whatsAppClient:   whatsAppClient,
+		waitlistNotifier: waitlistNotifier,
+	}
+}
+
+type PlusOneItem struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	DecidedBy      string     `json:"decided_by,omitempty"`
+	DecisionNote   string     `json:"decision_note,omitempty"`
+	UserID         string     `json:"user_id"`
+	UserName       string     `json:"user_name"`
+	WhatsAppNumber string     `json:"wa_number"`
+	InvitationID   string     `json:"invitation_id"`
+	InvitationName string     `json:"invitation_name"`
+	InvitationType string     `json:"invitation_type"`
+	MaxSeats       int64      `json:"max_seats"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DecidedAt      *time.Time `json:"decided_at"`
+}
+
+func newPlusOneItem(detail *store.PlusOneRequestDetailData) PlusOneItem {
+	item := PlusOneItem{
+		ID:             detail.Request.ID,
+		Name:           detail.Request.Name,
+		Status:         detail.Request.Status,
+		DecidedBy:      detail.Request.DecidedBy,
+		DecisionNote:   detail.Request.DecisionNote,
+		UserID:         detail.Request.UserID,
+		UserName:       detail.UserName,
+		WhatsAppNumber: detail.WhatsAppNumber,
+		InvitationID:   detail.Request.InvitationID,
+		InvitationName: detail.InvitationName,
+		InvitationType: detail.InvitationType,
+		MaxSeats:       detail.MaxSeats,
+		CreatedAt:      detail.Request.CreatedAt,
+	}
+	if detail.Request.DecidedAt.Valid {
+		item.DecidedAt = &detail.Request.DecidedAt.Time
+	}
+
+	return item
+}
+
+type GetPlusOneListResponse struct {
+	Items []PlusOneItem `json:"items"`
+}
+
+func newGetPlusOneListResponse(requestList []*store.PlusOneRequestDetailData) GetPlusOneListResponse {
+	resp := GetPlusOneListResponse{Items: make([]PlusOneItem, len(requestList))}
+	for idx, detail := range requestList {
+		resp.Items[idx] = newPlusOneItem(detail)
+	}
+
+	return resp
+}
+
+func (handler *plusOneHandler) notifyDecision(detail *store.PlusOneRequestDetailData) {
+	text := decisionMessage(detail)
+	go func() {
+		err := handler.whatsAppClient.SendMessage(context.Background(), detail.WhatsAppNumber, &waProto.Message{Conversation: &text})
+		if err != nil {
+			log.Println("error send plus-one decision message: %w", err)
+		}
+	}()
+}
+
+func decisionMessage(detail *store.PlusOneRequestDetailData) string {
+	greeting := "Hi"
+	if detail.UserName != "" {
+		greeting = "Hi " + detail.UserName
+	}
+
+	message := fmt.Sprintf("%s, unfortunately we are unable to accommodate %s as your plus-one. "+
+		"Thank you for understanding.", greeting, detail.Request.Name)
+	if detail.Request.Status == store.PlusOneStatusApproved {
+		message = fmt.Sprintf("%s, good news! %s is welcome to join you as your plus-one. "+
+			"Your invitation now admits %d people, please update your RSVP to include them.", greeting, detail.Request.Name, detail.MaxSeats)
+	}
+	if detail.Request.DecisionNote != "" {
+		message += "\n\n" + detail.Request.DecisionNote
+	}
+
+	return message
+}
@@ -0,0 +1,77 @@
+package plusone
+
+import (
+	"strings"
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func TestDecisionMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail *store.PlusOneRequestDetailData
+		want   string
+	}{
+		{
+			name: "approved",
+			detail: &store.PlusOneRequestDetailData{
+				Request:  store.PlusOneRequestData{Name: "Sari", Status: store.PlusOneStatusApproved},
+				UserName: "Budi",
+				MaxSeats: 2,
+			},
+			want: "Hi Budi, good news! Sari is welcome to join you as your plus-one. " +
+				"Your invitation now admits 2 people, please update your RSVP to include them.",
+		},
+		{
+			name: "rejected without user name",
+			detail: &store.PlusOneRequestDetailData{
+				Request: store.PlusOneRequestData{Name: "Sari", Status: store.PlusOneStatusRejected},
+			},
+			want: "Hi, unfortunately we are unable to accommodate Sari as your plus-one. Thank you for understanding.",
+		},
+		{
+			name: "rejected with note",
+			detail: &store.PlusOneRequestDetailData{
+				Request:  store.PlusOneRequestData{Name: "Sari", Status: store.PlusOneStatusRejected, DecisionNote: "The venue is full."},
+				UserName: "Budi",
+			},
+			want: "Hi Budi, unfortunately we are unable to accommodate Sari as your plus-one. Thank you for understanding." +
+				"\n\nThe venue is full.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decisionMessage(tt.detail); got != tt.want {
+				t.Errorf("decisionMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateUserPlusOneRequestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      CreateUserPlusOneRequest
+		wantErr  bool
+		wantName string
+	}{
+		{name: "valid", req: CreateUserPlusOneRequest{Name: "  Sari  "}, wantName: "Sari"},
+		{name: "longest name", req: CreateUserPlusOneRequest{Name: strings.Repeat("é", store.AttendeeNameMaxLength)}, wantName: strings.Repeat("é", store.AttendeeNameMaxLength)},
+		{name: "empty", req: CreateUserPlusOneRequest{Name: "  "}, wantErr: true},
+		{name: "too long", req: CreateUserPlusOneRequest{Name: strings.Repeat("a", store.AttendeeNameMaxLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if (fieldErr != nil) != tt.wantErr {
+				t.Fatalf("validate() = %v, wantErr %v", fieldErr, tt.wantErr)
+			}
+			if !tt.wantErr && tt.req.Name != tt.wantName {
+				t.Errorf("validate() name = %q, want %q", tt.req.Name, tt.wantName)
+			}
+		})
+	}
+}
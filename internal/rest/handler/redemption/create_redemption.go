@@ -0,0 +1,148 @@
+package redemption
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+type CreateRedemptionRequest struct {
+	Payload    string `json:"payload"`
+	UserID     string `json:"user_id"`
+	ItemID     string `json:"item_id"`
+	Quantity   int64  `json:"quantity"`
+	RedeemedBy string `json:"redeemed_by"`
+}
+
+func (r *CreateRedemptionRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.Payload = strings.TrimSpace(r.Payload)
+	r.UserID = strings.TrimSpace(r.UserID)
+	r.ItemID = strings.TrimSpace(r.ItemID)
+	r.RedeemedBy = strings.TrimSpace(r.RedeemedBy)
+
+	if (r.Payload == "") == (r.UserID == "") {
+		fieldErr = fieldErr.WithField("payload", "exactly one of payload or user_id is required")
+	}
+
+	if r.ItemID == "" {
+		fieldErr = fieldErr.WithField("item_id", "item_id is required")
+	}
+
+	if r.Quantity < 0 {
+		fieldErr = fieldErr.WithField("quantity", "quantity must be a positive integer")
+	}
+
+	if r.RedeemedBy == "" {
+		fieldErr = fieldErr.WithField("redeemed_by", "redeemed_by is required")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+type RedemptionResponse struct {
+	ID         string    `json:"id"`
+	ItemID     string    `json:"item_id"`
+	Quantity   int64     `json:"quantity"`
+	RedeemedBy string    `json:"redeemed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateRedemptionResponse struct {
+	Redemption RedemptionResponse `json:"redemption"`
+	RedemptionGuestResponse
+}
+
+func (handler *redemptionHandler) CreateRedemption(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := CreateRedemptionRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	guest, apiErr := handler.guestFinder.Find(ctx, req.Payload, req.UserID)
+	if apiErr != nil {
+		response.Error(w, *apiErr)
+		return
+	}
+
+	redemption := &store.RedemptionData{
+		ItemID:     req.ItemID,
+		UserID:     guest.User.ID,
+		Quantity:   req.Quantity,
+		RedeemedBy: req.RedeemedBy,
+	}
+
+	if err := handler.redemptionStore.Insert(ctx, redemption); err != nil {
+		var quotaErr *store.RedemptionQuotaError
+		var stockErr *store.RedemptionStockError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Redemption item not found"))
+		case errors.Is(err, store.ErrInvitationRevoked):
+			response.Error(w, apierror.GoneError("Invitation has been revoked").WithCode(apierror.CodeInvitationRevoked))
+		case errors.As(err, &quotaErr) && quotaErr.Left == 0:
+			response.Error(w, apierror.ConflictError(fmt.Sprintf(
+				"Invitation has already collected all %d of this item", quotaErr.Allowance,
+			)).WithCode(apierror.CodeRedemptionExceeded))
+		case errors.As(err, &quotaErr):
+			response.Error(w, apierror.ConflictError(fmt.Sprintf(
+				"Invitation only has %d of %d left to collect", quotaErr.Left, quotaErr.Allowance,
+			)).WithCode(apierror.CodeRedemptionExceeded))
+		case errors.As(err, &stockErr):
+			response.Error(w, apierror.ConflictError(fmt.Sprintf(
+				"Only %d of this item are left in stock", stockErr.StockLeft(),
+			)).WithCode(apierror.CodeOutOfStock))
+		default:
+			log.Println("error insert redemption data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	allowanceList, err := handler.redemptionStore.FindAllAllowanceByInvitationID(ctx, guest.Invitation.ID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp := CreateRedemptionResponse{
+		Redemption: RedemptionResponse{
+			ID:         redemption.ID,
+			ItemID:     redemption.ItemID,
+			Quantity:   redemption.Quantity,
+			RedeemedBy: redemption.RedeemedBy,
+			CreatedAt:  redemption.CreatedAt,
+		},
+		RedemptionGuestResponse: RedemptionGuestResponse{
+			Guest:      newGuestResponse(guest),
+			Allowances: newAllowanceListResponse(allowanceList),
+		},
+	}
+
+	response.Respond(w, http.StatusCreated, resp)
+}
@@ -0,0 +1,43 @@
+package redemption
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+func (handler *redemptionHandler) CreateRedemptionItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := RedemptionItemRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	newItem := req.toRedemptionItemData("")
+
+	if err := handler.redemptionStore.InsertItem(ctx, newItem); err != nil {
+		if errors.Is(err, store.ErrRedemptionItemNameTaken) {
+			response.FieldError(w, apierror.NewFieldError().WithField("name", "name is already used by another item"))
+			return
+		}
+		log.Println("error insert new redemption item data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusCreated, newRedemptionItemResponse(newItem))
+}
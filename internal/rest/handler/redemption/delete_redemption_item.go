@@ -0,0 +1,35 @@
+package redemption
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *redemptionHandler) DeleteRedemptionItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "id")
+
+	if err := handler.redemptionStore.DeleteItem(ctx, itemID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Redemption item not found"))
+		case errors.Is(err, store.ErrRedemptionItemInUse):
+			response.Error(w, apierror.ConflictError("Redemption item has already been redeemed and cannot be deleted"))
+		default:
+			log.Println("error delete redemption item data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	response.RespondSuccess(w)
+}
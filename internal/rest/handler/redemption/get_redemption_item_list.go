@@ -0,0 +1,32 @@
+package redemption
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type RedemptionItemListResponse struct {
+	Items []RedemptionItemResponse `json:"items"`
+}
+
+func (handler *redemptionHandler) GetRedemptionItemList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	itemList, err := handler.redemptionStore.FindAllItem(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]RedemptionItemResponse, len(itemList))
+	for idx, item := range itemList {
+		items[idx] = newRedemptionItemResponse(item)
+	}
+
+	response.Respond(w, http.StatusOK, RedemptionItemListResponse{Items: items})
+}
@@ -0,0 +1,57 @@
+package redemption
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type RedemptionStockItem struct {
+	Item            RedemptionItemResponse `json:"item"`
+	InvitationCount int64                  `json:"invitation_count"`
+	TotalAllowance  int64                  `json:"total_allowance"`
+	Redeemed        int64                  `json:"redeemed"`
+	Outstanding     int64                  `json:"outstanding"`
+	StockLeft       *int64                 `json:"stock_left"`
+	Shortfall       int64                  `json:"shortfall"`
+}
+
+type GetRedemptionStockResponse struct {
+	Items []RedemptionStockItem `json:"items"`
+}
+
+func (handler *redemptionHandler) GetRedemptionStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stockList, err := handler.redemptionStore.FindAllStock(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]RedemptionStockItem, len(stockList))
+	for idx, stock := range stockList {
+		items[idx] = RedemptionStockItem{
+			Item:            newRedemptionItemResponse(&stock.Item),
+			InvitationCount: stock.InvitationCount,
+			TotalAllowance:  stock.TotalAllowance,
+			Redeemed:        stock.TotalRedeemed,
+		}
+		if stock.TotalAllowance > stock.TotalRedeemed {
+			items[idx].Outstanding = stock.TotalAllowance - stock.TotalRedeemed
+		}
+		if stock.Item.Stock.Valid {
+			stockLeft := stock.Item.Stock.Int64 - stock.TotalRedeemed
+			items[idx].StockLeft = &stockLeft
+			if items[idx].Outstanding > stockLeft {
+				items[idx].Shortfall = items[idx].Outstanding - stockLeft
+			}
+		}
+	}
+
+	response.Respond(w, http.StatusOK, GetRedemptionStockResponse{Items: items})
+}
@@ -0,0 +1,170 @@
+package redemption
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"be-wedding/internal/config"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/rest/handler/guestqr"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/token"
+)
+
+type RedemptionHandler interface {
+	CreateRedemptionItem(w http.ResponseWriter, r *http.Request)
+	UpdateRedemptionItem(w http.ResponseWriter, r *http.Request)
+	DeleteRedemptionItem(w http.ResponseWriter, r *http.Request)
+	GetRedemptionItemList(w http.ResponseWriter, r *http.Request)
+	GetRedemptionStock(w http.ResponseWriter, r *http.Request)
+	VerifyRedemption(w http.ResponseWriter, r *http.Request)
+	CreateRedemption(w http.ResponseWriter, r *http.Request)
+}
+
+type redemptionHandler struct {
+	apiCfg          config.API
+	db              *sql.DB
+	redemptionStore store.Redemption
+	guestFinder     *guestqr.Finder
+}
+
+func NewRedemptionHandler(apiCfg config.API, db *sql.DB, userStore store.User, redemptionStore store.Redemption, jwt token.JWT) RedemptionHandler {
+	return &redemptionHandler{
+		apiCfg:          apiCfg,
+		db:              db,
+		redemptionStore: redemptionStore,
+		guestFinder:     guestqr.NewFinder(userStore, jwt),
+	}
+}
+
+type RedemptionItemRequest struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	AllowanceType string `json:"allowance_type"`
+	Allowance     int64  `json:"allowance"`
+	Stock         *int64 `json:"stock"`
+}
+
+func (r *RedemptionItemRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.AllowanceType = strings.ToUpper(strings.TrimSpace(r.AllowanceType))
+
+	if r.Name == "" {
+		fieldErr = fieldErr.WithField("name", "name is required")
+	}
+
+	if r.AllowanceType == "" {
+		r.AllowanceType = store.RedemptionAllowancePerPerson
+	}
+	if r.AllowanceType != store.RedemptionAllowancePerPerson && r.AllowanceType != store.RedemptionAllowancePerInvitation {
+		fieldErr = fieldErr.WithField("allowance_type", "allowance_type must be PER_PERSON or PER_INVITATION")
+	}
+
+	if r.Allowance == 0 {
+		r.Allowance = 1
+	}
+	if r.Allowance < 1 {
+		fieldErr = fieldErr.WithField("allowance", "allowance must be at least 1")
+	}
+
+	if r.Stock != nil && *r.Stock < 0 {
+		fieldErr = fieldErr.WithField("stock", "stock must not be negative")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+func (r *RedemptionItemRequest) toRedemptionItemData(id string) *store.RedemptionItemData {
+	item := &store.RedemptionItemData{
+		ID:            id,
+		Name:          r.Name,
+		Description:   r.Description,
+		AllowanceType: r.AllowanceType,
+		Allowance:     r.Allowance,
+	}
+	if r.Stock != nil {
+		item.Stock = sql.NullInt64{Int64: *r.Stock, Valid: true}
+	}
+
+	return item
+}
+
+type RedemptionItemResponse struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	AllowanceType string     `json:"allowance_type"`
+	Allowance     int64      `json:"allowance"`
+	Stock         *int64     `json:"stock"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
+
+func newRedemptionItemResponse(item *store.RedemptionItemData) RedemptionItemResponse {
+	resp := RedemptionItemResponse{
+		ID:            item.ID,
+		Name:          item.Name,
+		Description:   item.Description,
+		AllowanceType: item.AllowanceType,
+		Allowance:     item.Allowance,
+		CreatedAt:     item.CreatedAt,
+	}
+	if item.Stock.Valid {
+		resp.Stock = &item.Stock.Int64
+	}
+	if item.UpdatedAt.Valid {
+		resp.UpdatedAt = &item.UpdatedAt.Time
+	}
+
+	return resp
+}
+
+type GuestResponse struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	InvitationID   string `json:"invitation_id"`
+	InvitationName string `json:"invitation_name"`
+}
+
+func newGuestResponse(guest *store.UserCheckInData) GuestResponse {
+	return GuestResponse{
+		ID:             guest.User.ID,
+		Name:           guest.User.Name,
+		InvitationID:   guest.Invitation.ID,
+		InvitationName: guest.Invitation.Name,
+	}
+}
+
+type RedemptionAllowanceResponse struct {
+	Item      RedemptionItemResponse `json:"item"`
+	Allowance int64                  `json:"allowance"`
+	Redeemed  int64                  `json:"redeemed"`
+	Left      int64                  `json:"left"`
+	StockLeft *int64                 `json:"stock_left"`
+}
+
+func newAllowanceListResponse(allowanceList []*store.RedemptionAllowanceData) []RedemptionAllowanceResponse {
+	items := make([]RedemptionAllowanceResponse, len(allowanceList))
+	for idx, allowance := range allowanceList {
+		items[idx] = RedemptionAllowanceResponse{
+			Item:      newRedemptionItemResponse(&allowance.Item),
+			Allowance: allowance.Allowance,
+			Redeemed:  allowance.Redeemed,
+			Left:      allowance.Left(),
+		}
+		if stockLeft, ok := allowance.StockLeft(); ok {
+			items[idx].StockLeft = &stockLeft
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,207 @@
+package redemption
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/rest/handler/guestqr"
+	"be-wedding/internal/store"
+)
+
+func fieldNames(fieldErr *apierror.FieldError) map[string]bool {
+	names := map[string]bool{}
+	for _, field := range fieldErr.Fields {
+		names[field.Name] = true
+	}
+
+	return names
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestRedemptionItemRequestValidate(t *testing.T) {
+	tests := []struct {
+		name              string
+		req               RedemptionItemRequest
+		wantErr           []string
+		wantAllowanceType string
+		wantAllowance     int64
+	}{
+		{
+			name:              "defaults",
+			req:               RedemptionItemRequest{Name: " Souvenir "},
+			wantAllowanceType: store.RedemptionAllowancePerPerson,
+			wantAllowance:     1,
+		},
+		{
+			name:              "per invitation with stock",
+			req:               RedemptionItemRequest{Name: "Doorprize", AllowanceType: "per_invitation", Allowance: 2, Stock: int64Ptr(0)},
+			wantAllowanceType: store.RedemptionAllowancePerInvitation,
+			wantAllowance:     2,
+		},
+		{
+			name:    "missing name and unknown type",
+			req:     RedemptionItemRequest{AllowanceType: "PER_TABLE"},
+			wantErr: []string{"name", "allowance_type"},
+		},
+		{
+			name:    "negative allowance and stock",
+			req:     RedemptionItemRequest{Name: "Souvenir", Allowance: -1, Stock: int64Ptr(-1)},
+			wantErr: []string{"allowance", "stock"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) != 0 {
+				if fieldErr == nil {
+					t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+				}
+				names := fieldNames(fieldErr)
+				for _, field := range tt.wantErr {
+					if !names[field] {
+						t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+					}
+				}
+				return
+			}
+			if fieldErr != nil {
+				t.Fatalf("validate() = %v", fieldErr.Fields)
+			}
+			if tt.req.AllowanceType != tt.wantAllowanceType || tt.req.Allowance != tt.wantAllowance {
+				t.Errorf("validate() = (%s, %d), want (%s, %d)", tt.req.AllowanceType, tt.req.Allowance, tt.wantAllowanceType, tt.wantAllowance)
+			}
+		})
+	}
+}
+
+func TestCreateRedemptionRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateRedemptionRequest
+		wantErr []string
+	}{
+		{name: "payload", req: CreateRedemptionRequest{Payload: "token", ItemID: "i1", RedeemedBy: "usher"}},
+		{name: "user id with quantity", req: CreateRedemptionRequest{UserID: "u1", ItemID: "i1", Quantity: 2, RedeemedBy: "usher"}},
+		{name: "neither payload nor user id", req: CreateRedemptionRequest{ItemID: "i1", RedeemedBy: "usher"}, wantErr: []string{"payload"}},
+		{name: "both payload and user id", req: CreateRedemptionRequest{Payload: "token", UserID: "u1", ItemID: "i1", RedeemedBy: "usher"}, wantErr: []string{"payload"}},
+		{
+			name:    "missing item, negative quantity and no usher",
+			req:     CreateRedemptionRequest{UserID: "u1", ItemID: " ", Quantity: -1, RedeemedBy: " "},
+			wantErr: []string{"item_id", "quantity", "redeemed_by"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) == 0 {
+				if fieldErr != nil {
+					t.Errorf("validate() = %v", fieldErr.Fields)
+				}
+				return
+			}
+			if fieldErr == nil {
+				t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+			}
+			names := fieldNames(fieldErr)
+			for _, field := range tt.wantErr {
+				if !names[field] {
+					t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+				}
+			}
+		})
+	}
+}
+
+type fakeUserStore struct {
+	store.User
+}
+
+func (s *fakeUserStore) FindOneCheckInDataByID(ctx context.Context, id string) (*store.UserCheckInData, error) {
+	return &store.UserCheckInData{
+		User:       store.UserData{ID: id},
+		Invitation: store.InvitationData{ID: "inv1", Status: store.InvitationStatusUsed},
+	}, nil
+}
+
+type fakeRedemptionStore struct {
+	store.Redemption
+	insertErr error
+}
+
+func (s *fakeRedemptionStore) Insert(ctx context.Context, redemption *store.RedemptionData) error {
+	return s.insertErr
+}
+
+func (s *fakeRedemptionStore) FindAllAllowanceByInvitationID(ctx context.Context, invitationID string) ([]*store.RedemptionAllowanceData, error) {
+	return []*store.RedemptionAllowanceData{}, nil
+}
+
+func TestCreateRedemption(t *testing.T) {
+	tests := []struct {
+		name       string
+		insertErr  error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "redeemed", wantStatus: http.StatusCreated},
+		{name: "unknown item", insertErr: sql.ErrNoRows, wantStatus: http.StatusNotFound},
+		{name: "revoked meanwhile", insertErr: store.ErrInvitationRevoked, wantStatus: http.StatusGone, wantCode: apierror.CodeInvitationRevoked},
+		{
+			name:       "allowance used up",
+			insertErr:  &store.RedemptionQuotaError{Allowance: 2, Redeemed: 2},
+			wantStatus: http.StatusConflict,
+			wantCode:   apierror.CodeRedemptionExceeded,
+		},
+		{
+			name:       "allowance partly left",
+			insertErr:  &store.RedemptionQuotaError{Allowance: 2, Redeemed: 1, Left: 1},
+			wantStatus: http.StatusConflict,
+			wantCode:   apierror.CodeRedemptionExceeded,
+		},
+		{
+			name:       "out of stock",
+			insertErr:  &store.RedemptionStockError{Stock: 10, Redeemed: 10},
+			wantStatus: http.StatusConflict,
+			wantCode:   apierror.CodeOutOfStock,
+		},
+		{name: "store failure", insertErr: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &redemptionHandler{
+				redemptionStore: &fakeRedemptionStore{insertErr: tt.insertErr},
+				guestFinder:     guestqr.NewFinder(&fakeUserStore{}, nil),
+			}
+
+			body := `{"user_id":"u1","item_id":"item1","redeemed_by":"usher"}`
+			rec := httptest.NewRecorder()
+			handler.CreateRedemption(rec, httptest.NewRequest(http.MethodPost, "/redemptions", strings.NewReader(body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantCode != "" {
+				apiErr := apierror.Error{}
+				if err := json.NewDecoder(rec.Body).Decode(&apiErr); err != nil {
+					t.Fatalf("decode response: %v", err)
+				}
+				if apiErr.Code != tt.wantCode {
+					t.Errorf("code = %q, want %q", apiErr.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package redemption
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *redemptionHandler) UpdateRedemptionItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	itemID := chi.URLParam(r, "id")
+
+	req := RedemptionItemRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	item := req.toRedemptionItemData(itemID)
+
+	if err := handler.redemptionStore.UpdateItem(ctx, item); err != nil {
+		var stockErr *store.RedemptionStockError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Redemption item not found"))
+		case errors.Is(err, store.ErrRedemptionItemNameTaken):
+			response.FieldError(w, apierror.NewFieldError().WithField("name", "name is already used by another item"))
+		case errors.As(err, &stockErr):
+			response.FieldError(w, apierror.NewFieldError().WithField("stock",
+				fmt.Sprintf("stock cannot be lower than the %d already redeemed", stockErr.Redeemed)))
+		default:
+			log.Println("error update redemption item data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newRedemptionItemResponse(item))
+}
@@ -0,0 +1,49 @@
+package redemption
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type VerifyRedemptionRequest struct {
+	Payload string `json:"payload"`
+}
+
+type RedemptionGuestResponse struct {
+	Guest      GuestResponse                 `json:"guest"`
+	Allowances []RedemptionAllowanceResponse `json:"allowances"`
+}
+
+func (handler *redemptionHandler) VerifyRedemption(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := VerifyRedemptionRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	guest, apiErr := handler.guestFinder.Verify(ctx, req.Payload)
+	if apiErr != nil {
+		response.Error(w, *apiErr)
+		return
+	}
+
+	allowanceList, err := handler.redemptionStore.FindAllAllowanceByInvitationID(ctx, guest.Invitation.ID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, RedemptionGuestResponse{
+		Guest:      newGuestResponse(guest),
+		Allowances: newAllowanceListResponse(allowanceList),
+	})
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+func (handler *reportHandler) GetRSVPReport(w http.ResponseWriter, r *http.Request) {
+	resp, err := handler.rsvpReport(r.Context())
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
+
+func (handler *reportHandler) GetRSVPReportCSV(w http.ResponseWriter, r *http.Request) {
+	resp, err := handler.rsvpReport(r.Context())
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.WriteAll(resp.csvRecords())
+	if err := writer.Error(); err != nil {
+		log.Println("error write rsvp report csv: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	fileName := "rsvp-report-" + time.Now().Format("20060102") + ".csv"
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func (resp *RSVPReportResponse) csvRecords() [][]string {
+	records := [][]string{{
+		"group", "id", "name", "invitation_count", "max_seats", "user_count", "rsvp_user_count",
+		"declined_user_count", "maybe_user_count", "pending_user_count", "unregistered_invitation_count",
+		"confirmed_people_count", "maybe_people_count",
+	}}
+	records = append(records, resp.Total.csvRecord("total"))
+	for _, groups := range []struct {
+		name  string
+		items []HeadCountItem
+	}{
+		{"session", resp.Sessions},
+		{"invitation_type", resp.InvitationTypes},
+		{"tag", resp.Tags},
+	} {
+		for _, item := range groups.items {
+			records = append(records, item.csvRecord(groups.name))
+		}
+	}
+
+	records = append(records, nil, []string{"status", "user_count"})
+	for _, statusCount := range resp.Statuses {
+		records = append(records, []string{csvText(statusCount.Status), formatInt(statusCount.UserCount)})
+	}
+
+	records = append(records, nil, []string{"dietary", "attendee_count"},
+		[]string{"ATTENDEES", formatInt(resp.Dietary.AttendeeCount)},
+		[]string{"ADULTS", formatInt(resp.Dietary.AdultCount)},
+		[]string{"CHILDREN", formatInt(resp.Dietary.ChildCount)},
+		[]string{"WHEELCHAIR", formatInt(resp.Dietary.WheelchairCount)},
+		[]string{"UNLISTED_PEOPLE", formatInt(resp.Dietary.UnlistedPeople)},
+	)
+	for _, category := range resp.Dietary.Categories {
+		records = append(records, []string{category.Category, formatInt(category.AttendeeCount)})
+	}
+
+	records = append(records, nil, []string{
+		"session_name", "invitation_code", "invitation_name", "invitation_type", "user_name", "user_status", "wa_number",
+	})
+	for _, nonResponder := range resp.NonResponders {
+		records = append(records, []string{
+			csvText(nonResponder.SessionName), csvText(nonResponder.InvitationCode), csvText(nonResponder.InvitationName),
+			csvText(nonResponder.InvitationType), csvText(nonResponder.UserName), csvText(nonResponder.UserStatus),
+			csvText(nonResponder.WhatsAppNumber),
+		})
+	}
+
+	return records
+}
+
+func (item HeadCountItem) csvRecord(group string) []string {
+	return []string{
+		group, csvText(item.ID), csvText(item.Name),
+		formatInt(item.InvitationCount), formatInt(item.MaxSeats), formatInt(item.UserCount), formatInt(item.RSVPUserCount),
+		formatInt(item.DeclinedUserCount), formatInt(item.MaybeUserCount), formatInt(item.PendingUserCount),
+		formatInt(item.UnregisteredInvitationCount), formatInt(item.ConfirmedPeopleCount), formatInt(item.MaybePeopleCount),
+	}
+}
+
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func formatInt(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
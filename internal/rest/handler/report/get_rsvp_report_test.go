@@ -0,0 +1,52 @@
+package report
+
+import "testing"
+
+func TestCSVText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "Budi", want: "Budi"},
+		{value: "=HYPERLINK(\"http://x\")", want: "'=HYPERLINK(\"http://x\")"},
+		{value: "+6281234567890", want: "'+6281234567890"},
+		{value: "-1", want: "'-1"},
+		{value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{value: "a=b", want: "a=b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := csvText(tt.value); got != tt.want {
+				t.Errorf("csvText(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRSVPReportResponseCSVRecordsEscapeNonResponders(t *testing.T) {
+	resp := &RSVPReportResponse{
+		NonResponders: []NonResponderItem{{
+			InvitationCode: "=CODE",
+			InvitationName: "+Family",
+			InvitationType: "GROUP",
+			SessionName:    "@Session",
+			UserName:       "-Budi",
+			UserStatus:     "=STATUS",
+			WhatsAppNumber: "+6281234567890",
+		}},
+	}
+
+	records := resp.csvRecords()
+	got := records[len(records)-1]
+	want := []string{"'@Session", "'=CODE", "'+Family", "GROUP", "'-Budi", "'=STATUS", "'+6281234567890"}
+	if len(got) != len(want) {
+		t.Fatalf("non responder record = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("non responder record[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/store"
+)
+
+type ReportHandler interface {
+	GetRSVPReport(w http.ResponseWriter, r *http.Request)
+	GetRSVPReportCSV(w http.ResponseWriter, r *http.Request)
+}
+
+type reportHandler struct {
+	apiCfg      config.API
+	db          *sql.DB
+	reportStore store.Report
+	userStore   store.User
+}
+
+func NewReportHandler(apiCfg config.API, db *sql.DB, reportStore store.Report, userStore store.User) ReportHandler {
+	return &reportHandler{
+		apiCfg:      apiCfg,
+		db:          db,
+		reportStore: reportStore,
+		userStore:   userStore,
+	}
+}
+
+type HeadCountItem struct {
+	ID                          string `json:"id,omitempty"`
+	Name                        string `json:"name,omitempty"`
+	InvitationCount             int64  `json:"invitation_count"`
+	MaxSeats                    int64  `json:"max_seats"`
+	UserCount                   int64  `json:"user_count"`
+	RSVPUserCount               int64  `json:"rsvp_user_count"`
+	DeclinedUserCount           int64  `json:"declined_user_count"`
+	MaybeUserCount              int64  `json:"maybe_user_count"`
+	PendingUserCount            int64  `json:"pending_user_count"`
+	UnregisteredInvitationCount int64  `json:"unregistered_invitation_count"`
+	ConfirmedPeopleCount        int64  `json:"confirmed_people_count"`
+	MaybePeopleCount            int64  `json:"maybe_people_count"`
+}
+
+func (item *HeadCountItem) add(other HeadCountItem) {
+	item.InvitationCount += other.InvitationCount
+	item.MaxSeats += other.MaxSeats
+	item.UserCount += other.UserCount
+	item.RSVPUserCount += other.RSVPUserCount
+	item.DeclinedUserCount += other.DeclinedUserCount
+	item.MaybeUserCount += other.MaybeUserCount
+	item.PendingUserCount += other.PendingUserCount
+	item.UnregisteredInvitationCount += other.UnregisteredInvitationCount
+	item.ConfirmedPeopleCount += other.ConfirmedPeopleCount
+	item.MaybePeopleCount += other.MaybePeopleCount
+}
+
+func newHeadCountItems(headCountList []store.ReportHeadCountData) []HeadCountItem {
+	items := make([]HeadCountItem, len(headCountList))
+	for idx, headCount := range headCountList {
+		items[idx] = HeadCountItem{
+			ID:                          headCount.ID,
+			Name:                        headCount.Name,
+			InvitationCount:             headCount.InvitationCount,
+			MaxSeats:                    headCount.MaxSeats,
+			UserCount:                   headCount.UserCount,
+			RSVPUserCount:               headCount.RSVPUserCount,
+			DeclinedUserCount:           headCount.DeclinedUserCount,
+			MaybeUserCount:              headCount.MaybeUserCount,
+			PendingUserCount:            headCount.UserCount - headCount.RSVPUserCount,
+			UnregisteredInvitationCount: headCount.UnregisteredInvitationCount,
+			ConfirmedPeopleCount:        headCount.RSVPPeopleCount - headCount.MaybePeopleCount,
+			MaybePeopleCount:            headCount.MaybePeopleCount,
+		}
+	}
+
+	return items
+}
+
+type StatusCountItem struct {
+	Status    string `json:"status"`
+	UserCount int64  `json:"user_count"`
+}
+
+type DietaryCountItem struct {
+	Category      string `json:"category"`
+	AttendeeCount int64  `json:"attendee_count"`
+}
+
+type DietaryReport struct {
+	AttendeeCount   int64              `json:"attendee_count"`
+	AdultCount      int64              `json:"adult_count"`
+	ChildCount      int64              `json:"child_count"`
+	WheelchairCount int64              `json:"wheelchair_count"`
+	UnlistedPeople  int64              `json:"unlisted_people"`
+	Categories      []DietaryCountItem `json:"categories"`
+}
+
+type NonResponderItem struct {
+	InvitationID   string `json:"invitation_id"`
+	InvitationCode string `json:"invitation_code"`
+	InvitationName string `json:"invitation_name"`
+	InvitationType string `json:"invitation_type"`
+	SessionID      string `json:"session_id"`
+	SessionName    string `json:"session_name"`
+	UserID         string `json:"user_id,omitempty"`
+	UserName       string `json:"user_name,omitempty"`
+	UserStatus     string `json:"user_status,omitempty"`
+	WhatsAppNumber string `json:"wa_number"`
+}
+
+type RSVPReportResponse struct {
+	Total           HeadCountItem      `json:"total"`
+	Sessions        []HeadCountItem    `json:"sessions"`
+	InvitationTypes []HeadCountItem    `json:"invitation_types"`
+	Tags            []HeadCountItem    `json:"tags"`
+	Statuses        []StatusCountItem  `json:"statuses"`
+	Dietary         DietaryReport      `json:"dietary"`
+	NonResponders   []NonResponderItem `json:"non_responders"`
+}
+
+func (handler *reportHandler) rsvpReport(ctx context.Context) (*RSVPReportResponse, error) {
+	report, err := handler.reportStore.FindRSVPReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attendeeSummary, err := handler.userStore.FindRSVPAttendeeSummary(ctx, store.UserRSVPAttendeeFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &RSVPReportResponse{
+		Sessions:        newHeadCountItems(report.Sessions),
+		InvitationTypes: newHeadCountItems(report.InvitationTypes),
+		Tags:            newHeadCountItems(report.Tags),
+		Statuses:        make([]StatusCountItem, len(report.Statuses)),
+		Dietary: DietaryReport{
+			AttendeeCount:   attendeeSummary.AttendeeCount,
+			AdultCount:      attendeeSummary.AdultCount,
+			ChildCount:      attendeeSummary.ChildCount,
+			WheelchairCount: attendeeSummary.WheelchairCount,
+			UnlistedPeople:  attendeeSummary.UnlistedPeople,
+			Categories:      make([]DietaryCountItem, len(store.DietaryRestrictions)),
+		},
+		NonResponders: make([]NonResponderItem, len(report.NonResponders)),
+	}
+	for _, session := range resp.Sessions {
+		resp.Total.add(session)
+	}
+	for idx, statusCount := range report.Statuses {
+		resp.Statuses[idx] = StatusCountItem{
+			Status:    statusCount.Status,
+			UserCount: statusCount.UserCount,
+		}
+	}
+	for idx, category := range store.DietaryRestrictions {
+		resp.Dietary.Categories[idx] = DietaryCountItem{
+			Category:      category,
+			AttendeeCount: attendeeSummary.DietaryRestrictions[category],
+		}
+	}
+	for idx, nonResponder := range report.NonResponders {
+		resp.NonResponders[idx] = NonResponderItem{
+			InvitationID:   nonResponder.InvitationID,
+			InvitationCode: nonResponder.InvitationCode,
+			InvitationName: nonResponder.InvitationName,
+			InvitationType: nonResponder.InvitationType,
+			SessionID:      nonResponder.SessionID,
+			SessionName:    nonResponder.SessionName,
+			UserID:         nonResponder.UserID,
+			UserName:       nonResponder.UserName,
+			UserStatus:     nonResponder.UserStatus,
+			WhatsAppNumber: nonResponder.WhatsAppNumber,
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,37 @@
+package session
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+func (handler *sessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := SessionRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	newSession := req.toSessionData("")
+
+	if err := handler.invitationSessionStore.Insert(ctx, newSession); err != nil {
+		log.Println("error insert new invitation session data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusCreated, newSessionResponse(newSession))
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *sessionHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sessionID := chi.URLParam(r, "id")
+
+	if err := handler.invitationSessionStore.Delete(ctx, sessionID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Invitation session not found"))
+		case errors.Is(err, store.ErrInvitationSessionInUse):
+			response.Error(w, apierror.ConflictError("Invitation session still has invitations, move them to another session first"))
+		default:
+			log.Println("error delete invitation session data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	response.RespondSuccess(w)
+}
@@ -0,0 +1,26 @@
+package session
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *sessionHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sessionID := chi.URLParam(r, "id")
+
+	session, err := handler.invitationSessionStore.FindOneByID(ctx, sessionID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.NotFoundError("Invitation session not found"))
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newSessionResponse(session))
+}
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type SessionCapacityItem struct {
+	Session           SessionResponse `json:"session"`
+	InvitationCount   int64           `json:"invitation_count"`
+	AllocatedSeats    int64           `json:"allocated_seats"`
+	SeatsLeft         *int64          `json:"seats_left"`
+	UserCount         int64           `json:"user_count"`
+	RSVPUserCount     int64           `json:"rsvp_user_count"`
+	DeclinedUserCount int64           `json:"declined_user_count"`
+	MaybeUserCount    int64           `json:"maybe_user_count"`
+	PendingUserCount  int64           `json:"pending_user_count"`
+	RSVPPeopleCount   int64           `json:"rsvp_people_count"`
+	MaybePeopleCount  int64           `json:"maybe_people_count"`
+}
+
+type GetSessionCapacityListResponse struct {
+	Items []SessionCapacityItem `json:"items"`
+}
+
+func (handler *sessionHandler) GetSessionCapacityList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	loadList, err := handler.invitationSessionStore.FindAllLoad(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]SessionCapacityItem, len(loadList))
+	for idx, load := range loadList {
+		items[idx] = SessionCapacityItem{
+			Session:           newSessionResponse(&load.Session),
+			InvitationCount:   load.InvitationCount,
+			AllocatedSeats:    load.AllocatedSeats,
+			UserCount:         load.UserCount,
+			RSVPUserCount:     load.RSVPUserCount,
+			DeclinedUserCount: load.DeclinedUserCount,
+			MaybeUserCount:    load.MaybeUserCount,
+			PendingUserCount:  load.UserCount - load.RSVPUserCount,
+			RSVPPeopleCount:   load.RSVPPeopleCount,
+			MaybePeopleCount:  load.MaybePeopleCount,
+		}
+		if seatsLeft, ok := load.SeatsLeft(); ok {
+			items[idx].SeatsLeft = &seatsLeft
+		}
+	}
+
+	response.Respond(w, http.StatusOK, GetSessionCapacityListResponse{Items: items})
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type GetSessionListResponse struct {
+	Items []SessionResponse `json:"items"`
+}
+
+func (handler *sessionHandler) GetSessionList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sessionList, err := handler.invitationSessionStore.FindAll(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]SessionResponse, len(sessionList))
+	for idx, session := range sessionList {
+		items[idx] = newSessionResponse(session)
+	}
+
+	response.Respond(w, http.StatusOK, GetSessionListResponse{Items: items})
+}
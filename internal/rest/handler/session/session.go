@@ -0,0 +1,168 @@
+package session
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"be-wedding/internal/config"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+	"be-wedding/internal/waitlist"
+)
+
+type SessionHandler interface {
+	CreateSession(w http.ResponseWriter, r *http.Request)
+	UpdateSession(w http.ResponseWriter, r *http.Request)
+	DeleteSession(w http.ResponseWriter, r *http.Request)
+	GetSession(w http.ResponseWriter, r *http.Request)
+	GetSessionList(w http.ResponseWriter, r *http.Request)
+	GetSessionCapacityList(w http.ResponseWriter, r *http.Request)
+}
+
+type sessionHandler struct {
+	apiCfg                 config.API
+	db                     *sql.DB
+	invitationSessionStore store.InvitationSession
+	waitlistNotifier       *waitlist.Notifier
+}
+
+func NewSessionHandler(apiCfg config.API, db *sql.DB, invitationSessionStore store.InvitationSession, waitlistNotifier *waitlist.Notifier) SessionHandler {
+	return &sessionHandler{
+		apiCfg:                 apiCfg,
+		db:                     db,
+		invitationSessionStore: invitationSessionStore,
+		waitlistNotifier:       waitlistNotifier,
+	}
+}
+
+const sessionScheduleLayout = "15.04"
+
+type SessionRequest struct {
+	Name         string `json:"name"`
+	Schedule     string `json:"schedule"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	Venue        string `json:"venue"`
+	Capacity     *int64 `json:"capacity"`
+	RSVPOpensAt  string `json:"rsvp_opens_at"`
+	RSVPClosesAt string `json:"rsvp_closes_at"`
+
+	startTime    time.Time
+	endTime      time.Time
+	rsvpOpensAt  time.Time
+	rsvpClosesAt time.Time
+}
+
+func (r *SessionRequest) validate() *apierror.FieldError {
+	var err error
+	fieldErr := apierror.NewFieldError()
+
+	r.Name = strings.TrimSpace(r.Name)
+	r.Schedule = strings.TrimSpace(r.Schedule)
+	r.Venue = strings.TrimSpace(r.Venue)
+
+	if r.Name == "" {
+		fieldErr = fieldErr.WithField("name", "name is required")
+	}
+
+	r.startTime, err = time.Parse(time.RFC3339, r.StartTime)
+	if err != nil {
+		fieldErr = fieldErr.WithField("start_time", "start_time must be in RFC3339 format")
+	}
+
+	r.endTime, err = time.Parse(time.RFC3339, r.EndTime)
+	if err != nil {
+		fieldErr = fieldErr.WithField("end_time", "end_time must be in RFC3339 format")
+	} else if !r.startTime.IsZero() && !r.endTime.After(r.startTime) {
+		fieldErr = fieldErr.WithField("end_time", "end_time must be after start_time")
+	}
+
+	if r.Capacity != nil && *r.Capacity < 0 {
+		fieldErr = fieldErr.WithField("capacity", "capacity must not be negative")
+	}
+
+	if r.RSVPOpensAt != "" {
+		if r.rsvpOpensAt, err = time.Parse(time.RFC3339, r.RSVPOpensAt); err != nil {
+			fieldErr = fieldErr.WithField("rsvp_opens_at", "rsvp_opens_at must be in RFC3339 format")
+		}
+	}
+	if r.RSVPClosesAt != "" {
+		if r.rsvpClosesAt, err = time.Parse(time.RFC3339, r.RSVPClosesAt); err != nil {
+			fieldErr = fieldErr.WithField("rsvp_closes_at", "rsvp_closes_at must be in RFC3339 format")
+		} else if !r.rsvpOpensAt.IsZero() && !r.rsvpClosesAt.After(r.rsvpOpensAt) {
+			fieldErr = fieldErr.WithField("rsvp_closes_at", "rsvp_closes_at must be after rsvp_opens_at")
+		}
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	if r.Schedule == "" {
+		r.Schedule = r.startTime.Format(sessionScheduleLayout) + " - " + r.endTime.Format(sessionScheduleLayout)
+	}
+
+	return nil
+}
+
+func (r *SessionRequest) toSessionData(id string) *store.InvitationSessionData {
+	session := &store.InvitationSessionData{
+		ID:        id,
+		Name:      r.Name,
+		Schedule:  r.Schedule,
+		StartTime: sql.NullTime{Time: r.startTime.UTC(), Valid: true},
+		EndTime:   sql.NullTime{Time: r.endTime.UTC(), Valid: true},
+		Venue:     r.Venue,
+	}
+	if r.Capacity != nil {
+		session.Capacity = sql.NullInt64{Int64: *r.Capacity, Valid: true}
+	}
+	if !r.rsvpOpensAt.IsZero() {
+		session.RSVPOpensAt = sql.NullTime{Time: r.rsvpOpensAt.UTC(), Valid: true}
+	}
+	if !r.rsvpClosesAt.IsZero() {
+		session.RSVPClosesAt = sql.NullTime{Time: r.rsvpClosesAt.UTC(), Valid: true}
+	}
+
+	return session
+}
+
+type SessionResponse struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	StartTime    *time.Time `json:"start_time"`
+	EndTime      *time.Time `json:"end_time"`
+	Venue        string     `json:"venue"`
+	Capacity     *int64     `json:"capacity"`
+	RSVPOpensAt  *time.Time `json:"rsvp_opens_at"`
+	RSVPClosesAt *time.Time `json:"rsvp_closes_at"`
+}
+
+func newSessionResponse(session *store.InvitationSessionData) SessionResponse {
+	resp := SessionResponse{
+		ID:       session.ID,
+		Name:     session.Name,
+		Schedule: session.Schedule,
+		Venue:    session.Venue,
+	}
+	if session.StartTime.Valid {
+		resp.StartTime = &session.StartTime.Time
+	}
+	if session.EndTime.Valid {
+		resp.EndTime = &session.EndTime.Time
+	}
+	if session.Capacity.Valid {
+		resp.Capacity = &session.Capacity.Int64
+	}
+	if session.RSVPOpensAt.Valid {
+		resp.RSVPOpensAt = &session.RSVPOpensAt.Time
+	}
+	if session.RSVPClosesAt.Valid {
+		resp.RSVPClosesAt = &session.RSVPClosesAt.Time
+	}
+
+	return resp
+}
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"testing"
+
+	apierror "be-wedding/internal/rest/error"
+)
+
+func fieldNames(fieldErr *apierror.FieldError) map[string]bool {
+	names := map[string]bool{}
+	for _, field := range fieldErr.Fields {
+		names[field.Name] = true
+	}
+
+	return names
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestSessionRequestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          SessionRequest
+		wantErr      []string
+		wantSchedule string
+	}{
+		{
+			name:         "schedule from the times",
+			req:          SessionRequest{Name: " Akad ", StartTime: "2024-06-01T08:00:00+07:00", EndTime: "2024-06-01T10:30:00+07:00"},
+			wantSchedule: "08.00 - 10.30",
+		},
+		{
+			name:         "explicit schedule",
+			req:          SessionRequest{Name: "Resepsi", Schedule: "Siang", StartTime: "2024-06-01T11:00:00+07:00", EndTime: "2024-06-01T13:00:00+07:00"},
+			wantSchedule: "Siang",
+		},
+		{
+			name:    "missing name and invalid times",
+			req:     SessionRequest{StartTime: "08:00", EndTime: "10:30"},
+			wantErr: []string{"name", "start_time", "end_time"},
+		},
+		{
+			name:         "zero capacity",
+			req:          SessionRequest{Name: "Akad", StartTime: "2024-06-01T08:00:00+07:00", EndTime: "2024-06-01T10:30:00+07:00", Capacity: int64Ptr(0)},
+			wantSchedule: "08.00 - 10.30",
+		},
+		{
+			name:    "negative capacity",
+			req:     SessionRequest{Name: "Akad", StartTime: "2024-06-01T08:00:00+07:00", EndTime: "2024-06-01T10:30:00+07:00", Capacity: int64Ptr(-1)},
+			wantErr: []string{"capacity"},
+		},
+		{
+			name: "rsvp window",
+			req: SessionRequest{
+				Name: "Akad", StartTime: "2024-06-01T08:00:00+07:00", EndTime: "2024-06-01T10:30:00+07:00",
+				RSVPOpensAt: "2024-04-01T00:00:00+07:00", RSVPClosesAt: "2024-05-25T00:00:00+07:00",
+			},
+			wantSchedule: "08.00 - 10.30",
+		},
+		{
+			name: "rsvp closes before it opens",
+			req: SessionRequest{
+				Name: "Akad", StartTime: "2024-06-01T08:00:00+07:00", EndTime: "2024-06-01T10:30:00+07:00",
+				RSVPOpensAt: "2024-05-25T00:00:00+07:00", RSVPClosesAt: "2024-05-25T00:00:00+07:00",
+			},
+			wantErr: []string{"rsvp_closes_at"},
+		},
+		{
+			name: "invalid rsvp times",
+			req: SessionRequest{
+				Name: "Akad", StartTime: "2024-06-01T08:00:00+07:00", EndTime: "2024-06-01T10:30:00+07:00",
+				RSVPOpensAt: "April", RSVPClosesAt: "May",
+			},
+			wantErr: []string{"rsvp_opens_at", "rsvp_closes_at"},
+		},
+		{
+			name:    "end before start",
+			req:     SessionRequest{Name: "Akad", StartTime: "2024-06-01T10:00:00+07:00", EndTime: "2024-06-01T10:00:00+07:00"},
+			wantErr: []string{"end_time"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) != 0 {
+				if fieldErr == nil {
+					t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+				}
+				names := fieldNames(fieldErr)
+				for _, field := range tt.wantErr {
+					if !names[field] {
+						t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+					}
+				}
+				return
+			}
+			if fieldErr != nil {
+				t.Fatalf("validate() = %v", fieldErr.Fields)
+			}
+			if tt.req.Schedule != tt.wantSchedule {
+				t.Errorf("Schedule = %q, want %q", tt.req.Schedule, tt.wantSchedule)
+			}
+		})
+	}
+}
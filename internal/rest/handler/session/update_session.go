@@ -0,0 +1,48 @@
+package session
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *sessionHandler) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sessionID := chi.URLParam(r, "id")
+
+	req := SessionRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	sessionData := req.toSessionData(sessionID)
+
+	promoted, err := handler.invitationSessionStore.Update(ctx, sessionData)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Invitation session not found"))
+			return
+		}
+		log.Println("error update invitation session data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.Respond(w, http.StatusOK, newSessionResponse(sessionData))
+}
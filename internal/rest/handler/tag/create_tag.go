@@ -0,0 +1,46 @@
+package tag
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+)
+
+func (handler *tagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := TagRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	newTag := &store.TagData{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := handler.tagStore.Insert(ctx, newTag); err != nil {
+		if errors.Is(err, store.ErrTagNameTaken) {
+			response.FieldError(w, apierror.NewFieldError().WithField("name", "name is already used by another tag"))
+			return
+		}
+		log.Println("error insert new tag data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusCreated, newTagResponse(newTag))
+}
@@ -0,0 +1,31 @@
+package tag
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *tagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tagID := chi.URLParam(r, "id")
+
+	if err := handler.tagStore.Delete(ctx, tagID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Tag not found"))
+			return
+		}
+		log.Println("error delete tag data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.RespondSuccess(w)
+}
@@ -0,0 +1,56 @@
+package tag
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type TagHeadCountItem struct {
+	TagID             string `json:"tag_id"`
+	TagName           string `json:"tag_name"`
+	InvitationCount   int64  `json:"invitation_count"`
+	MaxSeats          int64  `json:"max_seats"`
+	UserCount         int64  `json:"user_count"`
+	RSVPUserCount     int64  `json:"rsvp_user_count"`
+	DeclinedUserCount int64  `json:"declined_user_count"`
+	MaybeUserCount    int64  `json:"maybe_user_count"`
+	PendingUserCount  int64  `json:"pending_user_count"`
+	RSVPPeopleCount   int64  `json:"rsvp_people_count"`
+}
+
+type GetTagHeadCountResponse struct {
+	Items []TagHeadCountItem `json:"items"`
+}
+
+func (handler *tagHandler) GetTagHeadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	headCountList, err := handler.tagStore.FindAllHeadCount(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	items := make([]TagHeadCountItem, len(headCountList))
+	for idx, headCount := range headCountList {
+		items[idx] = TagHeadCountItem{
+			TagID:             headCount.TagID,
+			TagName:           headCount.TagName,
+			InvitationCount:   headCount.InvitationCount,
+			MaxSeats:          headCount.MaxSeats,
+			UserCount:         headCount.UserCount,
+			RSVPUserCount:     headCount.RSVPUserCount,
+			DeclinedUserCount: headCount.DeclinedUserCount,
+			MaybeUserCount:    headCount.MaybeUserCount,
+			PendingUserCount:  headCount.UserCount - headCount.RSVPUserCount,
+			RSVPPeopleCount:   headCount.RSVPPeopleCount,
+		}
+	}
+
+	response.Respond(w, http.StatusOK, GetTagHeadCountResponse{Items: items})
+}
@@ -0,0 +1,23 @@
+package tag
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+func (handler *tagHandler) GetTagList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tagList, err := handler.tagStore.FindAll(ctx)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newTagListResponse(tagList))
+}
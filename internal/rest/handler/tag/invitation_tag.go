@@ -0,0 +1,123 @@
+package tag
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AddInvitationTagRequest struct {
+	TagIDs []string `json:"tag_ids"`
+}
+
+func (handler *tagHandler) findInvitation(w http.ResponseWriter, r *http.Request) (*store.InvitationData, bool) {
+	invitation, err := handler.invitationStore.FindOneByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Invitation id not found"))
+			return nil, false
+		}
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return nil, false
+	}
+
+	return invitation, true
+}
+
+func (handler *tagHandler) GetInvitationTagList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	invitation, ok := handler.findInvitation(w, r)
+	if !ok {
+		return
+	}
+
+	tagList, err := handler.tagStore.FindAllByInvitationID(ctx, invitation.ID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newTagListResponse(tagList))
+}
+
+func (handler *tagHandler) AddInvitationTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := AddInvitationTagRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if len(req.TagIDs) == 0 {
+		response.FieldError(w, apierror.NewFieldError().WithField("tag_ids", "tag_ids must contain at least one tag id"))
+		return
+	}
+
+	invitation, ok := handler.findInvitation(w, r)
+	if !ok {
+		return
+	}
+
+	for _, tagID := range req.TagIDs {
+		if _, err := handler.tagStore.FindOneByID(ctx, tagID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				response.FieldError(w, apierror.NewFieldError().WithField("tag_ids", "tag "+tagID+" not found"))
+				return
+			}
+			log.Println(err)
+			response.Error(w, apierror.InternalServerError())
+			return
+		}
+	}
+
+	if err := handler.tagStore.AddToInvitation(ctx, invitation.ID, req.TagIDs); err != nil {
+		log.Println("error add invitation tag data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	tagList, err := handler.tagStore.FindAllByInvitationID(ctx, invitation.ID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusCreated, newTagListResponse(tagList))
+}
+
+func (handler *tagHandler) RemoveInvitationTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tagID := chi.URLParam(r, "tagID")
+
+	invitation, ok := handler.findInvitation(w, r)
+	if !ok {
+		return
+	}
+
+	if err := handler.tagStore.RemoveFromInvitation(ctx, invitation.ID, tagID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Invitation does not have this tag"))
+			return
+		}
+		log.Println("error remove invitation tag data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.RespondSuccess(w)
+}
@@ -0,0 +1,87 @@
+package tag
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"be-wedding/internal/config"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+)
+
+type TagHandler interface {
+	CreateTag(w http.ResponseWriter, r *http.Request)
+	UpdateTag(w http.ResponseWriter, r *http.Request)
+	DeleteTag(w http.ResponseWriter, r *http.Request)
+	GetTagList(w http.ResponseWriter, r *http.Request)
+	GetTagHeadCount(w http.ResponseWriter, r *http.Request)
+	GetInvitationTagList(w http.ResponseWriter, r *http.Request)
+	AddInvitationTag(w http.ResponseWriter, r *http.Request)
+	RemoveInvitationTag(w http.ResponseWriter, r *http.Request)
+}
+
+type tagHandler struct {
+	apiCfg          config.API
+	db              *sql.DB
+	tagStore        store.Tag
+	invitationStore store.Invitation
+}
+
+func NewTagHandler(apiCfg config.API, db *sql.DB, tagStore store.Tag, invitationStore store.Invitation) TagHandler {
+	return &tagHandler{
+		apiCfg:          apiCfg,
+		db:              db,
+		tagStore:        tagStore,
+		invitationStore: invitationStore,
+	}
+}
+
+type TagRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *TagRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+
+	if r.Name == "" {
+		fieldErr = fieldErr.WithField("name", "name is required")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+type TagResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func newTagResponse(tag *store.TagData) TagResponse {
+	return TagResponse{
+		ID:          tag.ID,
+		Name:        tag.Name,
+		Description: tag.Description,
+	}
+}
+
+type TagListResponse struct {
+	Items []TagResponse `json:"items"`
+}
+
+func newTagListResponse(tagList []*store.TagData) TagListResponse {
+	items := make([]TagResponse, len(tagList))
+	for idx, tag := range tagList {
+		items[idx] = newTagResponse(tag)
+	}
+
+	return TagListResponse{Items: items}
+}
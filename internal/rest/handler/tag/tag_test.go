@@ -0,0 +1,45 @@
+package tag
+
+import (
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func TestTagRequestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      TagRequest
+		wantErr  bool
+		wantName string
+	}{
+		{name: "trimmed", req: TagRequest{Name: "  Family ", Description: " bride side "}, wantName: "Family"},
+		{name: "blank name", req: TagRequest{Name: "   "}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if (fieldErr != nil) != tt.wantErr {
+				t.Fatalf("validate() = %v, want error %v", fieldErr, tt.wantErr)
+			}
+			if !tt.wantErr && tt.req.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", tt.req.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestNewTagListResponse(t *testing.T) {
+	resp := newTagListResponse([]*store.TagData{
+		{ID: "t1", Name: "Family", Description: "Bride side"},
+		{ID: "t2", Name: "Office"},
+	})
+	if len(resp.Items) != 2 || resp.Items[0] != (TagResponse{ID: "t1", Name: "Family", Description: "Bride side"}) || resp.Items[1].ID != "t2" {
+		t.Errorf("newTagListResponse() = %+v", resp)
+	}
+
+	if empty := newTagListResponse(nil); empty.Items == nil {
+		t.Error("newTagListResponse(nil).Items is nil, want an empty list")
+	}
+}
@@ -0,0 +1,54 @@
+package tag
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *tagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tagID := chi.URLParam(r, "id")
+
+	req := TagRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	tag := &store.TagData{
+		ID:          tagID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := handler.tagStore.Update(ctx, tag); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apierror.NotFoundError("Tag not found"))
+		case errors.Is(err, store.ErrTagNameTaken):
+			response.FieldError(w, apierror.NewFieldError().WithField("name", "name is already used by another tag"))
+		default:
+			log.Println("error update tag data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
+		return
+	}
+
+	response.Respond(w, http.StatusOK, newTagResponse(tag))
+}
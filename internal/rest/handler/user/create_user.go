@@ -2,8 +2,8 @@ package user
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"image/color"
 	"log"
 	"net/http"
 
@@ -13,9 +13,6 @@ import (
 	"be-wedding/internal/rest/response"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/google/uuid"
-
-	qrcode "github.com/skip2/go-qrcode"
 )
 
 type CreateNewUserRequest struct {
@@ -51,27 +48,40 @@ func (handler *userHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	qrImageName := fmt.Sprintf("qr-%s.png", uuid.NewString())
-	err = qrcode.WriteColorFile("https://example.org", qrcode.Medium, 256, color.White, color.RGBA{110, 81, 59, 255}, fmt.Sprintf("./static/qr-codes/%s", qrImageName))
-	if err != nil {
-		log.Println(err.Error())
-		response.Error(w, apierror.BadRequestError(err.Error()))
-		return
-	}
-
 	newUserData := &store.UserData{
 		InvitationID:   invitation.ID,
 		InvitationType: invitation.Type,
 		WhatsAppNumber: req.WhatsAppNumber,
-		QRImageName:    qrImageName,
 	}
 
 	if err := handler.userStore.Insert(ctx, newUserData); err != nil {
-		log.Println("error insert new user data: %w", err)
-		response.Error(w, apierror.InternalServerError())
+		var seatQuotaErr *store.SeatQuotaError
+		switch {
+		case errors.As(err, &seatQuotaErr):
+			response.Error(w, apierror.BadRequestError(seatQuotaMessage(seatQuotaErr)))
+		case errors.Is(err, store.ErrInvitationRevoked):
+			response.Error(w, apierror.GoneError("Invitation has been revoked, please contact the host").WithCode(apierror.CodeInvitationRevoked))
+		case errors.Is(err, store.ErrInvitationExpired):
+			response.Error(w, apierror.GoneError("Invitation has expired, please contact the host").WithCode(apierror.CodeInvitationExpired))
+		default:
+			log.Println("error insert new user data: %w", err)
+			response.Error(w, apierror.InternalServerError())
+		}
 		return
 	}
 
+	if handler.qrRenderer.StoreImage() {
+		qrImageName := fmt.Sprintf("qr-%s.png", newUserData.ID)
+		if err := handler.storeQRImage(ctx, newUserData.ID, invitation.ID, qrImageName); err != nil {
+			log.Println("error store qr image: %w", err)
+		} else if err := handler.userStore.UpdateQRImage(ctx, newUserData.ID, qrImageName); err != nil {
+			log.Println("error update qr image: %w", err)
+			if deleteErr := handler.blobStorage.Delete(ctx, qrImageName); deleteErr != nil {
+				log.Println("error delete unused qr image: %w", deleteErr)
+			}
+		}
+	}
+
 	resp := CreateUserResponse{
 		Message: "success",
 		UserID:  newUserData.ID,
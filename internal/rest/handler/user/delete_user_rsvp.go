@@ -0,0 +1,40 @@
+package user
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"be-wedding/internal/event"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *userHandler) DeleteUserRSVP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	change, err := handler.userStore.DeleteUserRSVP(ctx, userID, handler.rsvpPolicy.Check(time.Now()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("User has no RSVP nor waitlist entry"))
+			return
+		}
+		handler.respondUserRSVPError(w, err)
+		return
+	}
+
+	handler.broker.Publish(event.TypeRSVP, event.RSVPData{
+		UserID:    userID,
+		Action:    store.UserRSVPActionCancelled,
+		CreatedAt: time.Now().UTC(),
+	})
+	handler.waitlistNotifier.Promoted(change.Promoted)
+
+	response.RespondSuccess(w)
+}
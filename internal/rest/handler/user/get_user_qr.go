@@ -0,0 +1,141 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/qr"
+	"be-wedding/pkg/token"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	qrFormatPNG = "png"
+	qrFormatSVG = "svg"
+)
+
+var qrContentTypes = map[string]string{
+	qrFormatPNG: "image/png",
+	qrFormatSVG: "image/svg+xml",
+}
+
+const qrCacheControl = "private, max-age=86400"
+
+func (handler *userHandler) GetUserQRPNG(w http.ResponseWriter, r *http.Request) {
+	handler.getUserQR(w, r, qrFormatPNG)
+}
+
+func (handler *userHandler) GetUserQRSVG(w http.ResponseWriter, r *http.Request) {
+	handler.getUserQR(w, r, qrFormatSVG)
+}
+
+func (handler *userHandler) getUserQR(w http.ResponseWriter, r *http.Request, format string) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	size := handler.qrRenderer.Size()
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		var err error
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < qr.MinSize || size > qr.MaxSize {
+			response.FieldError(w, apierror.NewFieldError().WithField("size", "size must be an integer between 64 and 1024"))
+			return
+		}
+	}
+
+	guest, err := handler.userStore.FindOneCheckInDataByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("User id not found"))
+			return
+		}
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	if guest.Invitation.Status == store.InvitationStatusRevoked {
+		response.Error(w, apierror.GoneError("Invitation has been revoked, please contact the host").WithCode(apierror.CodeInvitationRevoked))
+		return
+	}
+
+	etag := qrETag(guest.User.ID, guest.Invitation.ID, size, format, handler.qrRenderer.ConfigHash())
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", qrCacheControl)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	checkInToken, err := handler.jwt.CreateCheckInToken(token.CheckInClaim{
+		UserID:       guest.User.ID,
+		InvitationID: guest.Invitation.ID,
+	})
+	if err != nil {
+		log.Println("error create check-in token: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	var image []byte
+	if format == qrFormatSVG {
+		image, err = handler.qrRenderer.SVG(checkInToken, size)
+	} else {
+		image, err = handler.qrRenderer.PNG(checkInToken, size)
+	}
+	if err != nil {
+		log.Println("error render qr image: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	w.Header().Set("Content-Type", qrContentTypes[format])
+
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(image))
+}
+
+func qrETag(userID string, invitationID string, size int, format string, configHash string) string {
+	sum := sha256.Sum256([]byte(userID + "|" + invitationID + "|" + strconv.Itoa(size) + "|" + format + "|" + configHash))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (handler *userHandler) storeQRImage(ctx context.Context, userID string, invitationID string, key string) error {
+	checkInToken, err := handler.jwt.CreateCheckInToken(token.CheckInClaim{
+		UserID:       userID,
+		InvitationID: invitationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	image, err := handler.qrRenderer.PNG(checkInToken, handler.qrRenderer.Size())
+	if err != nil {
+		return err
+	}
+
+	return handler.blobStorage.Put(ctx, key, bytes.NewReader(image), "image/png")
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type UserRSVPResponse struct {
+	ID             string              `json:"id"`
+	Attendance     string              `json:"attendance"`
+	PeopleCount    int64               `json:"people_count"`
+	DeclineMessage string              `json:"decline_message,omitempty"`
+	Attendees      []response.Attendee `json:"attendees"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      *time.Time          `json:"updated_at"`
+}
+
+type UserRSVPHistoryItem struct {
+	ID                  string    `json:"id"`
+	Action              string    `json:"action"`
+	Attendance          string    `json:"attendance,omitempty"`
+	DeclineMessage      string    `json:"decline_message,omitempty"`
+	PeopleCount         *int64    `json:"people_count"`
+	PreviousPeopleCount *int64    `json:"previous_people_count"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type GetUserRSVPHistoryResponse struct {
+	Current *UserRSVPResponse     `json:"current"`
+	History []UserRSVPHistoryItem `json:"history"`
+}
+
+func (handler *userHandler) GetUserRSVPHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	resp := GetUserRSVPHistoryResponse{}
+
+	userRSVP, err := handler.userStore.FindOneUserRSVPByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	if userRSVP != nil {
+		resp.Current = &UserRSVPResponse{
+			ID:             userRSVP.ID,
+			Attendance:     userRSVP.Attendance,
+			PeopleCount:    userRSVP.PeopleCount,
+			DeclineMessage: userRSVP.DeclineMessage,
+			Attendees:      response.NewAttendeeList(userRSVP.Attendees),
+			CreatedAt:      userRSVP.CreatedAt,
+		}
+		if userRSVP.UpdatedAt.Valid {
+			resp.Current.UpdatedAt = &userRSVP.UpdatedAt.Time
+		}
+	}
+
+	historyList, err := handler.userStore.FindAllUserRSVPHistoryByUserID(ctx, userID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	resp.History = make([]UserRSVPHistoryItem, len(historyList))
+	for idx, history := range historyList {
+		resp.History[idx] = UserRSVPHistoryItem{
+			ID:             history.ID,
+			Action:         history.Action,
+			Attendance:     history.Attendance,
+			DeclineMessage: history.DeclineMessage,
+			CreatedAt:      history.CreatedAt,
+		}
+		if history.PeopleCount.Valid {
+			resp.History[idx].PeopleCount = &history.PeopleCount.Int64
+		}
+		if history.PreviousPeopleCount.Valid {
+			resp.History[idx].PreviousPeopleCount = &history.PreviousPeopleCount.Int64
+		}
+	}
+
+	response.Respond(w, http.StatusOK, resp)
+}
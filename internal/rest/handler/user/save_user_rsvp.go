@@ -0,0 +1,269 @@
+package user
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"be-wedding/internal/event"
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/rsvp"
+	"be-wedding/internal/store"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const maxDeclineMessageLength = 500
+
+const maxAttendeeNotesLength = 500
+
+type UserRSVPAttendeeRequest struct {
+	Name                string   `json:"name"`
+	AgeGroup            string   `json:"age_group"`
+	DietaryRestrictions []string `json:"dietary_restrictions"`
+	Wheelchair          bool     `json:"wheelchair"`
+	Notes               string   `json:"notes"`
+}
+
+func (r *UserRSVPAttendeeRequest) validate(fieldErr apierror.FieldError, field string) apierror.FieldError {
+	r.Name = strings.TrimSpace(r.Name)
+	r.AgeGroup = strings.ToUpper(strings.TrimSpace(r.AgeGroup))
+	r.Notes = strings.TrimSpace(r.Notes)
+
+	if r.Name == "" {
+		fieldErr = fieldErr.WithField(field+".name", "name is required")
+	}
+	if utf8.RuneCountInString(r.Name) > store.AttendeeNameMaxLength {
+		fieldErr = fieldErr.WithField(field+".name", fmt.Sprintf("name must be at most %d characters", store.AttendeeNameMaxLength))
+	}
+
+	if r.AgeGroup == "" {
+		r.AgeGroup = store.AttendeeAgeGroupAdult
+	}
+	if r.AgeGroup != store.AttendeeAgeGroupAdult && r.AgeGroup != store.AttendeeAgeGroupChild {
+		fieldErr = fieldErr.WithField(field+".age_group", "age_group must be ADULT or CHILD")
+	}
+
+	dietaryRestrictions := []string{}
+	for _, restriction := range r.DietaryRestrictions {
+		restriction = strings.ToUpper(strings.TrimSpace(restriction))
+		if !store.ContainsString(store.DietaryRestrictions, restriction) {
+			fieldErr = fieldErr.WithField(field+".dietary_restrictions",
+				fmt.Sprintf("dietary_restrictions must be among %s, put anything else in notes", strings.Join(store.DietaryRestrictions, ", ")))
+			continue
+		}
+		if !store.ContainsString(dietaryRestrictions, restriction) {
+			dietaryRestrictions = append(dietaryRestrictions, restriction)
+		}
+	}
+	r.DietaryRestrictions = dietaryRestrictions
+
+	if utf8.RuneCountInString(r.Notes) > maxAttendeeNotesLength {
+		fieldErr = fieldErr.WithField(field+".notes", fmt.Sprintf("notes must be at most %d characters", maxAttendeeNotesLength))
+	}
+
+	return fieldErr
+}
+
+type SaveUserRSVPRequest struct {
+	Attendance     string                    `json:"attendance"`
+	PeopleCount    int64                     `json:"people_count"`
+	DeclineMessage string                    `json:"decline_message"`
+	Attendees      []UserRSVPAttendeeRequest `json:"attendees"`
+}
+
+func (r *SaveUserRSVPRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.Attendance = strings.ToUpper(strings.TrimSpace(r.Attendance))
+	r.DeclineMessage = strings.TrimSpace(r.DeclineMessage)
+
+	switch r.Attendance {
+	case "":
+		r.Attendance = store.UserRSVPAttendanceYes
+		fallthrough
+	case store.UserRSVPAttendanceYes, store.UserRSVPAttendanceMaybe:
+		if r.PeopleCount < 1 {
+			fieldErr = fieldErr.WithField("people_count", "people_count must be at least 1")
+		}
+		if r.DeclineMessage != "" {
+			fieldErr = fieldErr.WithField("decline_message", "decline_message is only accepted when attendance is NO")
+		}
+		if len(r.Attendees) != 0 && int64(len(r.Attendees)) != r.PeopleCount {
+			fieldErr = fieldErr.WithField("attendees", fmt.Sprintf("attendees must list exactly %d people to match people_count", r.PeopleCount))
+		}
+		for idx := range r.Attendees {
+			fieldErr = r.Attendees[idx].validate(fieldErr, fmt.Sprintf("attendees[%d]", idx))
+		}
+	case store.UserRSVPAttendanceNo:
+		if r.PeopleCount != 0 {
+			fieldErr = fieldErr.WithField("people_count", "people_count must be empty when attendance is NO")
+		}
+		if utf8.RuneCountInString(r.DeclineMessage) > maxDeclineMessageLength {
+			fieldErr = fieldErr.WithField("decline_message", fmt.Sprintf("decline_message must be at most %d characters", maxDeclineMessageLength))
+		}
+		if len(r.Attendees) != 0 {
+			fieldErr = fieldErr.WithField("attendees", "attendees must be empty when attendance is NO")
+		}
+	default:
+		fieldErr = fieldErr.WithField("attendance", "attendance must be YES, NO or MAYBE")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+func (r *SaveUserRSVPRequest) attendees() []store.UserRSVPAttendeeData {
+	attendees := make([]store.UserRSVPAttendeeData, len(r.Attendees))
+	for idx, attendee := range r.Attendees {
+		attendees[idx] = store.UserRSVPAttendeeData{
+			Name:                attendee.Name,
+			AgeGroup:            attendee.AgeGroup,
+			DietaryRestrictions: attendee.DietaryRestrictions,
+			Wheelchair:          attendee.Wheelchair,
+			Notes:               attendee.Notes,
+		}
+	}
+
+	return attendees
+}
+
+type SaveUserRSVPResponse struct {
+	Message        string              `json:"message"`
+	UserRSVPID     string              `json:"user_rsvp_id"`
+	Attendance     string              `json:"attendance"`
+	PeopleCount    int64               `json:"people_count"`
+	DeclineMessage string              `json:"decline_message,omitempty"`
+	Attendees      []response.Attendee `json:"attendees"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      *time.Time          `json:"updated_at"`
+}
+
+type UserRSVPWaitlistResponse struct {
+	Message     string              `json:"message"`
+	WaitlistID  string              `json:"waitlist_id"`
+	Position    int64               `json:"position"`
+	Attendance  string              `json:"attendance"`
+	PeopleCount int64               `json:"people_count"`
+	Attendees   []response.Attendee `json:"attendees"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   *time.Time          `json:"updated_at"`
+}
+
+func (handler *userHandler) SaveUserRSVP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := chi.URLParam(r, "id")
+
+	req := SaveUserRSVPRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	userRSVP := &store.UserRSVPData{
+		UserID:         userID,
+		Attendance:     req.Attendance,
+		PeopleCount:    req.PeopleCount,
+		DeclineMessage: req.DeclineMessage,
+		Attendees:      req.attendees(),
+	}
+
+	change, err := handler.userStore.SaveUserRSVP(ctx, userRSVP, handler.rsvpPolicy.Check(time.Now()))
+	if err != nil {
+		handler.respondUserRSVPError(w, err)
+		return
+	}
+
+	if change.Waitlist != nil {
+		resp := UserRSVPWaitlistResponse{
+			Message:     "waitlisted",
+			WaitlistID:  change.Waitlist.ID,
+			Position:    change.Waitlist.Position,
+			Attendance:  change.Waitlist.Attendance,
+			PeopleCount: change.Waitlist.PeopleCount,
+			Attendees:   response.NewAttendeeList(change.Waitlist.Attendees),
+			CreatedAt:   change.Waitlist.CreatedAt,
+		}
+		if change.Waitlist.UpdatedAt.Valid {
+			resp.UpdatedAt = &change.Waitlist.UpdatedAt.Time
+		}
+
+		response.Respond(w, http.StatusAccepted, resp)
+		return
+	}
+	handler.waitlistNotifier.Promoted(change.Promoted)
+
+	action := store.UserRSVPActionCreated
+	status := http.StatusCreated
+	changedAt := userRSVP.CreatedAt
+	if userRSVP.UpdatedAt.Valid {
+		action = store.UserRSVPActionUpdated
+		status = http.StatusOK
+		changedAt = userRSVP.UpdatedAt.Time
+	}
+
+	handler.broker.Publish(event.TypeRSVP, event.RSVPData{
+		UserRSVPID:  userRSVP.ID,
+		UserID:      userRSVP.UserID,
+		Action:      action,
+		Attendance:  userRSVP.Attendance,
+		PeopleCount: userRSVP.PeopleCount,
+		CreatedAt:   changedAt,
+	})
+
+	resp := SaveUserRSVPResponse{
+		Message:        "success",
+		UserRSVPID:     userRSVP.ID,
+		Attendance:     userRSVP.Attendance,
+		PeopleCount:    userRSVP.PeopleCount,
+		DeclineMessage: userRSVP.DeclineMessage,
+		Attendees:      response.NewAttendeeList(userRSVP.Attendees),
+		CreatedAt:      userRSVP.CreatedAt,
+	}
+	if userRSVP.UpdatedAt.Valid {
+		resp.UpdatedAt = &userRSVP.UpdatedAt.Time
+	}
+
+	response.Respond(w, status, resp)
+}
+
+func (handler *userHandler) respondUserRSVPError(w http.ResponseWriter, err error) {
+	var seatQuotaErr *store.SeatQuotaError
+	var windowErr *rsvp.WindowError
+	switch {
+	case errors.As(err, &seatQuotaErr):
+		response.Error(w, apierror.BadRequestError(seatQuotaMessage(seatQuotaErr)))
+	case errors.As(err, &windowErr) && windowErr.Status == rsvp.StatusNotOpen:
+		response.Error(w, apierror.ForbiddenError(fmt.Sprintf("RSVP opens on %s", windowErr.Window.OpensAt.Format(time.RFC1123))).WithCode(apierror.CodeRSVPNotOpen))
+	case errors.As(err, &windowErr):
+		response.Error(w, apierror.ForbiddenError(fmt.Sprintf("RSVP closed on %s, please contact the host", windowErr.Window.ClosesAt.Format(time.RFC1123))).WithCode(apierror.CodeRSVPClosed))
+	case errors.Is(err, sql.ErrNoRows):
+		response.Error(w, apierror.NotFoundError("User id not found"))
+	case errors.Is(err, store.ErrAlreadyCheckedIn):
+		response.Error(w, apierror.ConflictError("User has already checked in, their RSVP can no longer change").WithCode(apierror.CodeAlreadyCheckedIn))
+	case errors.Is(err, store.ErrInvitationRevoked):
+		response.Error(w, apierror.GoneError("Invitation has been revoked, please contact the host").WithCode(apierror.CodeInvitationRevoked))
+	case errors.Is(err, store.ErrInvitationExpired):
+		response.Error(w, apierror.GoneError("Invitation has expired, please contact the host").WithCode(apierror.CodeInvitationExpired))
+	default:
+		log.Println("error save user rsvp data: %w", err)
+		response.Error(w, apierror.InternalServerError())
+	}
+}
@@ -0,0 +1,156 @@
+package user
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/store"
+)
+
+func fieldNames(fieldErr *apierror.FieldError) map[string]bool {
+	names := map[string]bool{}
+	for _, field := range fieldErr.Fields {
+		names[field.Name] = true
+	}
+
+	return names
+}
+
+func TestUserRSVPAttendeeRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     UserRSVPAttendeeRequest
+		want    UserRSVPAttendeeRequest
+		wantErr []string
+	}{
+		{
+			name: "normalized",
+			req:  UserRSVPAttendeeRequest{Name: " Ani ", AgeGroup: "child", DietaryRestrictions: []string{" halal", "HALAL", "vegetarian"}, Notes: " high chair "},
+			want: UserRSVPAttendeeRequest{Name: "Ani", AgeGroup: store.AttendeeAgeGroupChild, DietaryRestrictions: []string{"HALAL", "VEGETARIAN"}, Notes: "high chair"},
+		},
+		{
+			name: "adult by default",
+			req:  UserRSVPAttendeeRequest{Name: "Budi"},
+			want: UserRSVPAttendeeRequest{Name: "Budi", AgeGroup: store.AttendeeAgeGroupAdult, DietaryRestrictions: []string{}},
+		},
+		{
+			name:    "missing name and unknown age group",
+			req:     UserRSVPAttendeeRequest{Name: " ", AgeGroup: "TEEN"},
+			wantErr: []string{"attendees[0].name", "attendees[0].age_group"},
+		},
+		{
+			name:    "unknown dietary restriction",
+			req:     UserRSVPAttendeeRequest{Name: "Budi", DietaryRestrictions: []string{"NO_ONIONS"}},
+			wantErr: []string{"attendees[0].dietary_restrictions"},
+		},
+		{
+			name:    "too long",
+			req:     UserRSVPAttendeeRequest{Name: strings.Repeat("é", store.AttendeeNameMaxLength+1), Notes: strings.Repeat("a", maxAttendeeNotesLength+1)},
+			wantErr: []string{"attendees[0].name", "attendees[0].notes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate(apierror.NewFieldError(), "attendees[0]")
+			if len(tt.wantErr) != 0 {
+				names := fieldNames(&fieldErr)
+				for _, field := range tt.wantErr {
+					if !names[field] {
+						t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+					}
+				}
+				return
+			}
+			if len(fieldErr.Fields) != 0 {
+				t.Fatalf("validate() = %v", fieldErr.Fields)
+			}
+			if !reflect.DeepEqual(tt.req, tt.want) {
+				t.Errorf("validate() request = %+v, want %+v", tt.req, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveUserRSVPRequestValidate(t *testing.T) {
+	twoAttendees := []UserRSVPAttendeeRequest{{Name: "Budi"}, {Name: "Ani"}}
+
+	tests := []struct {
+		name           string
+		req            SaveUserRSVPRequest
+		wantErr        []string
+		wantAttendance string
+	}{
+		{
+			name:           "yes by default",
+			req:            SaveUserRSVPRequest{PeopleCount: 2, Attendees: twoAttendees},
+			wantAttendance: store.UserRSVPAttendanceYes,
+		},
+		{
+			name:           "maybe without attendees",
+			req:            SaveUserRSVPRequest{Attendance: "maybe", PeopleCount: 3},
+			wantAttendance: store.UserRSVPAttendanceMaybe,
+		},
+		{
+			name:           "declined",
+			req:            SaveUserRSVPRequest{Attendance: "NO", DeclineMessage: "Selamat ya!"},
+			wantAttendance: store.UserRSVPAttendanceNo,
+		},
+		{
+			name:    "attendees do not match people count",
+			req:     SaveUserRSVPRequest{PeopleCount: 3, Attendees: twoAttendees},
+			wantErr: []string{"attendees"},
+		},
+		{
+			name:    "attendee errors are indexed",
+			req:     SaveUserRSVPRequest{PeopleCount: 2, Attendees: []UserRSVPAttendeeRequest{{Name: "Budi"}, {}}},
+			wantErr: []string{"attendees[1].name"},
+		},
+		{
+			name:    "no people and a decline message",
+			req:     SaveUserRSVPRequest{Attendance: "YES", DeclineMessage: "maaf"},
+			wantErr: []string{"people_count", "decline_message"},
+		},
+		{
+			name:    "declined with people and attendees",
+			req:     SaveUserRSVPRequest{Attendance: "NO", PeopleCount: 2, Attendees: twoAttendees},
+			wantErr: []string{"people_count", "attendees"},
+		},
+		{
+			name:    "decline message too long",
+			req:     SaveUserRSVPRequest{Attendance: "NO", DeclineMessage: strings.Repeat("a", maxDeclineMessageLength+1)},
+			wantErr: []string{"decline_message"},
+		},
+		{
+			name:    "unknown attendance",
+			req:     SaveUserRSVPRequest{Attendance: "PERHAPS", PeopleCount: 1},
+			wantErr: []string{"attendance"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := tt.req.validate()
+			if len(tt.wantErr) != 0 {
+				if fieldErr == nil {
+					t.Fatalf("validate() = nil, want errors on %v", tt.wantErr)
+				}
+				names := fieldNames(fieldErr)
+				for _, field := range tt.wantErr {
+					if !names[field] {
+						t.Errorf("validate() fields = %v, missing %s", fieldErr.Fields, field)
+					}
+				}
+				return
+			}
+			if fieldErr != nil {
+				t.Fatalf("validate() = %v", fieldErr.Fields)
+			}
+			if tt.req.Attendance != tt.wantAttendance {
+				t.Errorf("Attendance = %s, want %s", tt.req.Attendance, tt.wantAttendance)
+			}
+		})
+	}
+}
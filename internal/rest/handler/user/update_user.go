@@ -1,7 +1,9 @@
 package user
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
@@ -40,6 +42,10 @@ func (handler *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := handler.userStore.Update(ctx, userData); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("User id not found"))
+			return
+		}
 		log.Println("error update new user data: %w", err)
 		response.Error(w, apierror.InternalServerError())
 		return
@@ -2,10 +2,17 @@ package user
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
 
 	"be-wedding/internal/config"
+	"be-wedding/internal/event"
+	"be-wedding/internal/rsvp"
 	"be-wedding/internal/store"
+	"be-wedding/internal/waitlist"
+	"be-wedding/pkg/qr"
+	"be-wedding/pkg/storage"
+	"be-wedding/pkg/token"
 )
 
 type UserHandler interface {
@@ -16,23 +23,47 @@ type UserHandler interface {
 	GetUserCommentList(w http.ResponseWriter, r *http.Request)
 	LikeUnlikeComment(w http.ResponseWriter, r *http.Request)
 	GetUserSpecificComment(w http.ResponseWriter, r *http.Request)
-	CreateUserRSVP(w http.ResponseWriter, r *http.Request)
+	SaveUserRSVP(w http.ResponseWriter, r *http.Request)
+	DeleteUserRSVP(w http.ResponseWriter, r *http.Request)
+	GetUserRSVPHistory(w http.ResponseWriter, r *http.Request)
+	GetUserQRPNG(w http.ResponseWriter, r *http.Request)
+	GetUserQRSVG(w http.ResponseWriter, r *http.Request)
 	RemindUserWeddingDate(w http.ResponseWriter, r *http.Request)
 	RemindUserSendWeddingVideo(w http.ResponseWriter, r *http.Request)
 }
 
 type userHandler struct {
-	apiCfg          config.API
-	db              *sql.DB
-	userStore       store.User
-	invitationStore store.Invitation
+	apiCfg           config.API
+	db               *sql.DB
+	userStore        store.User
+	invitationStore  store.Invitation
+	jwt              token.JWT
+	broker           *event.Broker
+	blobStorage      storage.Storage
+	qrRenderer       *qr.Renderer
+	rsvpPolicy       *rsvp.Policy
+	waitlistNotifier *waitlist.Notifier
 }
 
-func NewUserHandler(apiCfg config.API, db *sql.DB, userStore store.User, invitationStore store.Invitation) UserHandler {
+func NewUserHandler(apiCfg config.API, db *sql.DB, userStore store.User, invitationStore store.Invitation, jwt token.JWT, broker *event.Broker, blobStorage storage.Storage, qrRenderer *qr.Renderer, rsvpPolicy *rsvp.Policy, waitlistNotifier *waitlist.Notifier) UserHandler {
 	return &userHandler{
-		apiCfg:          apiCfg,
-		db:              db,
-		userStore:       userStore,
-		invitationStore: invitationStore,
+		apiCfg:           apiCfg,
+		db:               db,
+		userStore:        userStore,
+		invitationStore:  invitationStore,
+		jwt:              jwt,
+		broker:           broker,
+		blobStorage:      blobStorage,
+		qrRenderer:       qrRenderer,
+		rsvpPolicy:       rsvpPolicy,
+		waitlistNotifier: waitlistNotifier,
 	}
 }
+
+func seatQuotaMessage(err *store.SeatQuotaError) string {
+	if err.SeatsLeft == 0 {
+		return fmt.Sprintf("Invitation is full, all %d seats have been taken", err.MaxSeats)
+	}
+
+	return fmt.Sprintf("Invitation only has %d of %d seats left", err.SeatsLeft, err.MaxSeats)
+}
@@ -0,0 +1,33 @@
+package waitlist
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (handler *waitlistHandler) DeleteWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	waitlistID := chi.URLParam(r, "id")
+
+	promoted, err := handler.userStore.DeleteRSVPWaitlist(ctx, waitlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Waitlist id not found"))
+			return
+		}
+		log.Println("error delete waitlist: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.Respond(w, http.StatusOK, PromotedWaitlistResponse{Items: newWaitlistItems(promoted)})
+}
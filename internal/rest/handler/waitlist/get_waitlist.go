@@ -0,0 +1,27 @@
+package waitlist
+
+import (
+	"log"
+	"net/http"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type GetWaitlistResponse struct {
+	Items []WaitlistItem `json:"items"`
+}
+
+func (handler *waitlistHandler) GetWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	waitlistList, err := handler.userStore.FindAllRSVPWaitlist(ctx, r.URL.Query().Get("session_id"))
+	if err != nil {
+		log.Println(err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+
+	response.Respond(w, http.StatusOK, GetWaitlistResponse{Items: newWaitlistItems(waitlistList)})
+}
@@ -0,0 +1,63 @@
+package waitlist
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	apierror "be-wedding/internal/rest/error"
+
+	"be-wedding/internal/rest/response"
+)
+
+type PromoteWaitlistRequest struct {
+	SessionID string `json:"session_id"`
+}
+
+func (r *PromoteWaitlistRequest) validate() *apierror.FieldError {
+	fieldErr := apierror.NewFieldError()
+
+	r.SessionID = strings.TrimSpace(r.SessionID)
+	if r.SessionID == "" {
+		fieldErr = fieldErr.WithField("session_id", "session_id is required")
+	}
+
+	if len(fieldErr.Fields) != 0 {
+		return &fieldErr
+	}
+
+	return nil
+}
+
+func (handler *waitlistHandler) PromoteWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := PromoteWaitlistRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierror.BadRequestError(err.Error()))
+		return
+	}
+
+	if fieldErr := req.validate(); fieldErr != nil {
+		response.FieldError(w, *fieldErr)
+		return
+	}
+
+	promoted, err := handler.userStore.PromoteRSVPWaitlist(ctx, req.SessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apierror.NotFoundError("Session id not found"))
+			return
+		}
+		log.Println("error promote waitlist: %w", err)
+		response.Error(w, apierror.InternalServerError())
+		return
+	}
+	handler.waitlistNotifier.Promoted(promoted)
+
+	response.Respond(w, http.StatusOK, PromotedWaitlistResponse{Items: newWaitlistItems(promoted)})
+}
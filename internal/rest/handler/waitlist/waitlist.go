@@ -0,0 +1,83 @@
+package waitlist
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"be-wedding/internal/config"
+	"be-wedding/internal/rest/response"
+	"be-wedding/internal/store"
+	"be-wedding/internal/waitlist"
+)
+
+type WaitlistHandler interface {
+	GetWaitlist(w http.ResponseWriter, r *http.Request)
+	PromoteWaitlist(w http.ResponseWriter, r *http.Request)
+	DeleteWaitlist(w http.ResponseWriter, r *http.Request)
+}
+
+type waitlistHandler struct {
+	apiCfg           config.API
+	db               *sql.DB
+	userStore        store.User
+	waitlistNotifier *waitlist.Notifier
+}
+
+func NewWaitlistHandler(apiCfg config.API, db *sql.DB, userStore store.User, waitlistNotifier *waitlist.Notifier) WaitlistHandler {
+	return &waitlistHandler{
+		apiCfg:           apiCfg,
+		db:               db,
+		userStore:        userStore,
+		waitlistNotifier: waitlistNotifier,
+	}
+}
+
+type WaitlistItem struct {
+	ID             string              `json:"id"`
+	Position       int64               `json:"position"`
+	Attendance     string              `json:"attendance"`
+	PeopleCount    int64               `json:"people_count"`
+	HeldSeats      int64               `json:"held_seats"`
+	Attendees      []response.Attendee `json:"attendees"`
+	UserID         string              `json:"user_id"`
+	UserName       string              `json:"user_name"`
+	WhatsAppNumber string              `json:"wa_number"`
+	InvitationID   string              `json:"invitation_id"`
+	InvitationName string              `json:"invitation_name"`
+	SessionID      string              `json:"session_id"`
+	SessionName    string              `json:"session_name"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      *time.Time          `json:"updated_at"`
+}
+
+func newWaitlistItems(waitlistList []*store.UserRSVPWaitlistDetailData) []WaitlistItem {
+	items := make([]WaitlistItem, len(waitlistList))
+	for idx, detail := range waitlistList {
+		items[idx] = WaitlistItem{
+			ID:             detail.Waitlist.ID,
+			Position:       detail.Waitlist.Position,
+			Attendance:     detail.Waitlist.Attendance,
+			PeopleCount:    detail.Waitlist.PeopleCount,
+			HeldSeats:      detail.HeldSeats,
+			Attendees:      response.NewAttendeeList(detail.Waitlist.Attendees),
+			UserID:         detail.Waitlist.UserID,
+			UserName:       detail.UserName,
+			WhatsAppNumber: detail.WhatsAppNumber,
+			InvitationID:   detail.InvitationID,
+			InvitationName: detail.InvitationName,
+			SessionID:      detail.SessionID,
+			SessionName:    detail.SessionName,
+			CreatedAt:      detail.Waitlist.CreatedAt,
+		}
+		if detail.Waitlist.UpdatedAt.Valid {
+			items[idx].UpdatedAt = &detail.Waitlist.UpdatedAt.Time
+		}
+	}
+
+	return items
+}
+
+type PromotedWaitlistResponse struct {
+	Items []WaitlistItem `json:"items"`
+}
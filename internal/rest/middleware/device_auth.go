@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	apierror "be-wedding/internal/rest/error"
+	"be-wedding/internal/rest/response"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+func DeviceAuth(keys []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			deviceKey, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || !validDeviceKey(keys, deviceKey) {
+				response.Error(rw, apierror.UnauthorizedError("invalid device key"))
+				return
+			}
+
+			next.ServeHTTP(rw, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func validDeviceKey(keys []string, deviceKey string) bool {
+	if deviceKey == "" {
+		return false
+	}
+
+	valid := false
+	for _, key := range keys {
+		if key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(deviceKey)) == 1 {
+			valid = true
+		}
+	}
+
+	return valid
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeviceAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		keys          []string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "valid key", keys: []string{"gate-1", "gate-2"}, authorization: "Bearer gate-2", wantStatus: http.StatusOK},
+		{name: "unknown key", keys: []string{"gate-1"}, authorization: "Bearer gate-3", wantStatus: http.StatusUnauthorized},
+		{name: "missing header", keys: []string{"gate-1"}, wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", keys: []string{"gate-1"}, authorization: "gate-1", wantStatus: http.StatusUnauthorized},
+		{name: "no keys configured", authorization: "Bearer ", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := DeviceAuth(tt.keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/checkins/manifest", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package response
+
+import "be-wedding/internal/store"
+
+type Attendee struct {
+	Name                string   `json:"name"`
+	AgeGroup            string   `json:"age_group"`
+	DietaryRestrictions []string `json:"dietary_restrictions"`
+	Wheelchair          bool     `json:"wheelchair"`
+	Notes               string   `json:"notes,omitempty"`
+}
+
+func NewAttendee(attendee store.UserRSVPAttendeeData) Attendee {
+	return Attendee{
+		Name:                attendee.Name,
+		AgeGroup:            attendee.AgeGroup,
+		DietaryRestrictions: attendee.DietaryRestrictions,
+		Wheelchair:          attendee.Wheelchair,
+		Notes:               attendee.Notes,
+	}
+}
+
+func NewAttendeeList(attendeeList []store.UserRSVPAttendeeData) []Attendee {
+	attendees := make([]Attendee, len(attendeeList))
+	for idx, attendee := range attendeeList {
+		attendees[idx] = NewAttendee(attendee)
+	}
+
+	return attendees
+}
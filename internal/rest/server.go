@@ -2,10 +2,25 @@ package rest
 
 import (
 	"be-wedding/internal/config"
+	"be-wedding/internal/event"
+	attendeehandler "be-wedding/internal/rest/handler/attendee"
+	checkinhandler "be-wedding/internal/rest/handler/checkin"
+	dashboardhandler "be-wedding/internal/rest/handler/dashboard"
 	invitationhandler "be-wedding/internal/rest/handler/invitation"
+	passhandler "be-wedding/internal/rest/handler/pass"
+	plusonehandler "be-wedding/internal/rest/handler/plusone"
+	redemptionhandler "be-wedding/internal/rest/handler/redemption"
+	reporthandler "be-wedding/internal/rest/handler/report"
+	sessionhandler "be-wedding/internal/rest/handler/session"
+	taghandler "be-wedding/internal/rest/handler/tag"
 	userhandler "be-wedding/internal/rest/handler/user"
+	waitlisthandler "be-wedding/internal/rest/handler/waitlist"
 	"be-wedding/internal/rest/middleware"
+	"be-wedding/internal/rsvp"
 	storepgsql "be-wedding/internal/store/pgsql"
+	"be-wedding/internal/waitlist"
+	"be-wedding/pkg/qr"
+	"be-wedding/pkg/storage"
 	"be-wedding/pkg/token"
 	"be-wedding/pkg/whatsapp"
 	"database/sql"
@@ -22,6 +37,9 @@ func New(
 	zlogger zerolog.Logger,
 	sqlDB *sql.DB,
 	whatsAppClient whatsapp.Client,
+	blobStorage storage.Storage,
+	qrRenderer *qr.Renderer,
+	rsvpPolicy *rsvp.Policy,
 
 ) http.Handler {
 	r := chi.NewRouter()
@@ -36,14 +54,63 @@ func New(
 	)
 
 	invitationStore := storepgsql.NewInvitation(sqlDB)
+	invitationSessionStore := storepgsql.NewInvitationSession(sqlDB)
 	userStore := storepgsql.NewUser(sqlDB)
+	tagStore := storepgsql.NewTag(sqlDB)
+	checkInStore := storepgsql.NewCheckIn(sqlDB)
+	redemptionStore := storepgsql.NewRedemption(sqlDB)
+	reportStore := storepgsql.NewReport(sqlDB)
+	plusOneStore := storepgsql.NewPlusOne(sqlDB)
 
-	invitationHandler := invitationhandler.NewInvitationHandler(cfg.API, sqlDB, invitationStore)
-	userHandler := userhandler.NewUserHandler(cfg.API, sqlDB, userStore, invitationStore)
+	jwt := token.NewJWT(cfg.JWT)
+	broker := event.NewBroker()
+	waitlistNotifier := waitlist.NewNotifier(whatsAppClient, broker)
+
+	invitationHandler := invitationhandler.NewInvitationHandler(cfg.API, sqlDB, invitationStore, invitationSessionStore, blobStorage, waitlistNotifier)
+	sessionHandler := sessionhandler.NewSessionHandler(cfg.API, sqlDB, invitationSessionStore, waitlistNotifier)
+	tagHandler := taghandler.NewTagHandler(cfg.API, sqlDB, tagStore, invitationStore)
+	userHandler := userhandler.NewUserHandler(cfg.API, sqlDB, userStore, invitationStore, jwt, broker, blobStorage, qrRenderer, rsvpPolicy, waitlistNotifier)
+	checkInHandler := checkinhandler.NewCheckInHandler(cfg.API, sqlDB, userStore, checkInStore, jwt, broker)
+	dashboardHandler := dashboardhandler.NewDashboardHandler(cfg.API, sqlDB, checkInStore, broker)
+	passHandler := passhandler.NewPassHandler(cfg.API, sqlDB, userStore, invitationStore, jwt, qrRenderer)
+	redemptionHandler := redemptionhandler.NewRedemptionHandler(cfg.API, sqlDB, userStore, redemptionStore, jwt)
+	attendeeHandler := attendeehandler.NewAttendeeHandler(cfg.API, sqlDB, userStore)
+	reportHandler := reporthandler.NewReportHandler(cfg.API, sqlDB, reportStore, userStore)
+	waitlistHandler := waitlisthandler.NewWaitlistHandler(cfg.API, sqlDB, userStore, waitlistNotifier)
+	plusOneHandler := plusonehandler.NewPlusOneHandler(cfg.API, sqlDB, plusOneStore, whatsAppClient, waitlistNotifier)
 
 	r.Route("/invitations", func(r chi.Router) {
+		r.Get("/", invitationHandler.GetInvitationList)
 		r.Get("/{id}", invitationHandler.GetInvitationCompleteData)
 		r.Post("/", invitationHandler.CreateInvitation)
+		r.Put("/{id}", invitationHandler.UpdateInvitation)
+		r.Delete("/{id}", invitationHandler.DeleteInvitation)
+		r.Post("/import", invitationHandler.ImportInvitation)
+		r.Post("/{id}/revoke", invitationHandler.RevokeInvitation)
+		r.Post("/{id}/reinstate", invitationHandler.ReinstateInvitation)
+		r.Post("/{id}/rsvp-unlock", invitationHandler.UnlockInvitationRSVP)
+		r.Delete("/{id}/rsvp-unlock", invitationHandler.LockInvitationRSVP)
+		r.Get("/{id}/tags", tagHandler.GetInvitationTagList)
+		r.Post("/{id}/tags", tagHandler.AddInvitationTag)
+		r.Delete("/{id}/tags/{tagID}", tagHandler.RemoveInvitationTag)
+		r.Get("/{id}/pass.pdf", passHandler.GetInvitationPass)
+	})
+
+	r.Route("/sessions", func(r chi.Router) {
+		r.Get("/", sessionHandler.GetSessionList)
+		r.Get("/capacity", sessionHandler.GetSessionCapacityList)
+		r.Get("/{id}", sessionHandler.GetSession)
+		r.Post("/", sessionHandler.CreateSession)
+		r.Put("/{id}", sessionHandler.UpdateSession)
+		r.Delete("/{id}", sessionHandler.DeleteSession)
+	})
+
+	r.Route("/tags", func(r chi.Router) {
+		r.Get("/", tagHandler.GetTagList)
+		r.Get("/headcount", tagHandler.GetTagHeadCount)
+		r.Post("/", tagHandler.CreateTag)
+		r.Put("/{id}", tagHandler.UpdateTag)
+		r.Delete("/{id}", tagHandler.DeleteTag)
 	})
 
 	r.Route("/users", func(r chi.Router) {
@@ -54,18 +121,83 @@ func New(
 		r.Put("/{id}/comment/like", userHandler.LikeUnlikeComment)
 		r.Get("/{id}/comments", userHandler.GetUserCommentList)
 		r.Get("/{id}/comments/specific", userHandler.GetUserSpecificComment)
-		r.Post("/{id}/rsvp", userHandler.CreateUserRSVP)
+		r.Post("/{id}/rsvp", userHandler.SaveUserRSVP)
+		r.Put("/{id}/rsvp", userHandler.SaveUserRSVP)
+		r.Delete("/{id}/rsvp", userHandler.DeleteUserRSVP)
+		r.Get("/{id}/rsvp/history", userHandler.GetUserRSVPHistory)
+		r.Get("/{id}/qr.png", userHandler.GetUserQRPNG)
+		r.Get("/{id}/qr.svg", userHandler.GetUserQRSVG)
+		r.Get("/{id}/pass.pdf", passHandler.GetUserPass)
+		r.Post("/{id}/plus-one", plusOneHandler.CreateUserPlusOne)
+		r.Get("/{id}/plus-one", plusOneHandler.GetUserPlusOneList)
 		r.Post("/{id}/reminder/date", userHandler.RemindUserWeddingDate)
 		r.Post("/{id}/reminder/video", userHandler.RemindUserSendWeddingVideo)
 	})
 
+	r.Route("/attendees", func(r chi.Router) {
+		r.Get("/", attendeeHandler.GetAttendeeList)
+		r.Get("/summary", attendeeHandler.GetAttendeeSummary)
+	})
+
+	r.Route("/waitlist", func(r chi.Router) {
+		r.Get("/", waitlistHandler.GetWaitlist)
+		r.Post("/promote", waitlistHandler.PromoteWaitlist)
+		r.Delete("/{id}", waitlistHandler.DeleteWaitlist)
+	})
+
+	r.Route("/plus-one-requests", func(r chi.Router) {
+		r.Get("/", plusOneHandler.GetPlusOneList)
+		r.Post("/{id}/approve", plusOneHandler.ApprovePlusOne)
+		r.Post("/{id}/reject", plusOneHandler.RejectPlusOne)
+	})
+
+	r.Route("/checkins", func(r chi.Router) {
+		r.Post("/", checkInHandler.CreateCheckIn)
+		r.Post("/verify", checkInHandler.VerifyCheckIn)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.DeviceAuth(cfg.CheckIn.DeviceKeys))
+			r.Post("/sync", checkInHandler.SyncCheckIn)
+			r.Get("/manifest", checkInHandler.GetCheckInManifest)
+			r.Get("/manifest/key", checkInHandler.GetCheckInManifestKey)
+		})
+	})
+
+	r.Route("/redemption-items", func(r chi.Router) {
+		r.Get("/", redemptionHandler.GetRedemptionItemList)
+		r.Get("/stock", redemptionHandler.GetRedemptionStock)
+		r.Post("/", redemptionHandler.CreateRedemptionItem)
+		r.Put("/{id}", redemptionHandler.UpdateRedemptionItem)
+		r.Delete("/{id}", redemptionHandler.DeleteRedemptionItem)
+	})
+
+	r.Route("/redemptions", func(r chi.Router) {
+		r.Post("/", redemptionHandler.CreateRedemption)
+		r.Post("/verify", redemptionHandler.VerifyRedemption)
+	})
+
+	r.Route("/passes", func(r chi.Router) {
+		r.Get("/sheet.pdf", passHandler.GetPassSheet)
+	})
+
+	r.Route("/dashboard", func(r chi.Router) {
+		r.Get("/attendance", dashboardHandler.GetAttendanceSummary)
+		r.Get("/attendance/stream", dashboardHandler.StreamAttendance)
+	})
+
+	r.Route("/reports", func(r chi.Router) {
+		r.Get("/rsvp", reportHandler.GetRSVPReport)
+		r.Get("/rsvp.csv", reportHandler.GetRSVPReportCSV)
+	})
+
 	r.Route("/auth", func(r chi.Router) {
 		r.Get("/", token.HandleMain)
 	})
 
-	// STATIC FILE SERVE (FOR DEVELOPMENT PURPOSE ONLY)
-	fs := http.FileServer(http.Dir("static/qr-codes"))
-	r.Handle("/static/*", http.StripPrefix("/static/", fs))
+	if cfg.Storage.Backend == "" || cfg.Storage.Backend == storage.BackendLocal {
+		fs := http.FileServer(http.Dir(cfg.Storage.Local.Dir))
+		r.Handle("/static/*", http.StripPrefix("/static/", fs))
+	}
 
 	return r
 }
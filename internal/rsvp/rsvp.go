@@ -0,0 +1,135 @@
+// Package rsvp decides when guests may still give, change or cancel their RSVP, so the caterer
+// can be handed final numbers ahead of the event.
+package rsvp
+
+import (
+	"fmt"
+	"time"
+
+	"be-wedding/internal/store"
+)
+
+// Config is the window of every session that does not set its own.
+type Config struct {
+	// OpenAt and CloseAt are RFC3339 times, leave them empty for a window without that bound.
+	OpenAt  string `toml:"open_at"`
+	CloseAt string `toml:"close_at"`
+	// CloseDaysBeforeSession closes the window that many days before the session starts,
+	// or at CloseAt when that comes first. 0 disables it.
+	CloseDaysBeforeSession int `toml:"close_days_before_session"`
+}
+
+const (
+	StatusOpen    = "OPEN"
+	StatusNotOpen = "NOT_OPEN"
+	StatusClosed  = "CLOSED"
+)
+
+// Window bounds the RSVP changes of a session, a zero time leaves that side unbounded.
+type Window struct {
+	OpensAt  time.Time
+	ClosesAt time.Time
+}
+
+// Status tells whether now falls before, inside or after the window.
+func (w Window) Status(now time.Time) string {
+	switch {
+	case !w.OpensAt.IsZero() && now.Before(w.OpensAt):
+		return StatusNotOpen
+	case !w.ClosesAt.IsZero() && !now.Before(w.ClosesAt):
+		return StatusClosed
+	default:
+		return StatusOpen
+	}
+}
+
+type Policy struct {
+	openAt      time.Time
+	closeAt     time.Time
+	closeBefore time.Duration
+}
+
+func NewPolicy(cfg Config) (*Policy, error) {
+	policy := &Policy{
+		closeBefore: time.Duration(cfg.CloseDaysBeforeSession) * 24 * time.Hour,
+	}
+
+	var err error
+	if cfg.OpenAt != "" {
+		if policy.openAt, err = time.Parse(time.RFC3339, cfg.OpenAt); err != nil {
+			return nil, fmt.Errorf("rsvp: invalid open_at: %w", err)
+		}
+	}
+	if cfg.CloseAt != "" {
+		if policy.closeAt, err = time.Parse(time.RFC3339, cfg.CloseAt); err != nil {
+			return nil, fmt.Errorf("rsvp: invalid close_at: %w", err)
+		}
+	}
+	if !policy.openAt.IsZero() && !policy.closeAt.IsZero() && !policy.openAt.Before(policy.closeAt) {
+		return nil, fmt.Errorf("rsvp: open_at must be before close_at")
+	}
+	if cfg.CloseDaysBeforeSession < 0 {
+		return nil, fmt.Errorf("rsvp: close_days_before_session must not be negative")
+	}
+
+	return policy, nil
+}
+
+// Window returns the window of a session: its own bounds when set, otherwise the configured ones.
+func (p *Policy) Window(session store.InvitationSessionData) Window {
+	window := Window{OpensAt: p.openAt, ClosesAt: p.closeAt}
+
+	if session.RSVPOpensAt.Valid {
+		window.OpensAt = session.RSVPOpensAt.Time
+	}
+
+	if session.RSVPClosesAt.Valid {
+		window.ClosesAt = session.RSVPClosesAt.Time
+	} else if p.closeBefore > 0 && session.StartTime.Valid {
+		closesAt := session.StartTime.Time.Add(-p.closeBefore)
+		if window.ClosesAt.IsZero() || closesAt.Before(window.ClosesAt) {
+			window.ClosesAt = closesAt
+		}
+	}
+
+	return window
+}
+
+// Status tells whether the guests of the invitation may change their RSVP at now. An invitation unlocked
+// by the host is open until its unlock expires, whatever the window of its session.
+func (p *Policy) Status(session store.InvitationSessionData, invitation store.InvitationData, now time.Time) (Window, string) {
+	window := p.Window(session)
+	if invitation.RSVPUnlockedUntil.Valid && now.Before(invitation.RSVPUnlockedUntil.Time) {
+		return window, StatusOpen
+	}
+
+	return window, window.Status(now)
+}
+
+// WindowError is returned by the check of Check when the window is not open.
+type WindowError struct {
+	Window Window
+	// Status is StatusNotOpen or StatusClosed.
+	Status string
+}
+
+func (e *WindowError) Error() string {
+	if e.Status == StatusNotOpen {
+		return fmt.Sprintf("rsvp: opens on %s", e.Window.OpensAt.Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("rsvp: closed on %s", e.Window.ClosesAt.Format(time.RFC3339))
+}
+
+// Check returns the check the store runs on the locked session and invitation of an RSVP change,
+// it fails with a *WindowError outside the window at now.
+func (p *Policy) Check(now time.Time) store.RSVPCheck {
+	return func(session store.InvitationSessionData, invitation store.InvitationData) error {
+		window, status := p.Status(session, invitation, now)
+		if status != StatusOpen {
+			return &WindowError{Window: window, Status: status}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+package rsvp
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"be-wedding/internal/store"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("time.Parse(%q): %v", value, err)
+	}
+
+	return parsed
+}
+
+func validTime(t *testing.T, value string) sql.NullTime {
+	return sql.NullTime{Time: mustTime(t, value), Valid: true}
+}
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "empty", cfg: Config{}},
+		{name: "both bounds", cfg: Config{OpenAt: "2024-01-01T00:00:00Z", CloseAt: "2024-06-01T00:00:00Z"}},
+		{name: "open only", cfg: Config{OpenAt: "2024-01-01T00:00:00Z"}},
+		{name: "invalid open_at", cfg: Config{OpenAt: "tomorrow"}, wantErr: true},
+		{name: "invalid close_at", cfg: Config{CloseAt: "2024-06-01"}, wantErr: true},
+		{name: "open after close", cfg: Config{OpenAt: "2024-06-02T00:00:00Z", CloseAt: "2024-06-01T00:00:00Z"}, wantErr: true},
+		{name: "open at close", cfg: Config{OpenAt: "2024-06-01T00:00:00Z", CloseAt: "2024-06-01T00:00:00Z"}, wantErr: true},
+		{name: "negative days", cfg: Config{CloseDaysBeforeSession: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPolicy(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyWindow(t *testing.T) {
+	cfg := Config{OpenAt: "2024-01-01T00:00:00Z", CloseAt: "2024-06-01T00:00:00Z", CloseDaysBeforeSession: 7}
+	tests := []struct {
+		name    string
+		cfg     Config
+		session store.InvitationSessionData
+		want    Window
+	}{
+		{
+			name: "configured",
+			cfg:  cfg,
+			want: Window{OpensAt: mustTime(t, "2024-01-01T00:00:00Z"), ClosesAt: mustTime(t, "2024-06-01T00:00:00Z")},
+		},
+		{
+			name:    "days before session come first",
+			cfg:     cfg,
+			session: store.InvitationSessionData{StartTime: validTime(t, "2024-05-20T10:00:00Z")},
+			want:    Window{OpensAt: mustTime(t, "2024-01-01T00:00:00Z"), ClosesAt: mustTime(t, "2024-05-13T10:00:00Z")},
+		},
+		{
+			name:    "close_at comes first",
+			cfg:     cfg,
+			session: store.InvitationSessionData{StartTime: validTime(t, "2024-07-20T10:00:00Z")},
+			want:    Window{OpensAt: mustTime(t, "2024-01-01T00:00:00Z"), ClosesAt: mustTime(t, "2024-06-01T00:00:00Z")},
+		},
+		{
+			name:    "days before session without close_at",
+			cfg:     Config{CloseDaysBeforeSession: 2},
+			session: store.InvitationSessionData{StartTime: validTime(t, "2024-07-20T10:00:00Z")},
+			want:    Window{ClosesAt: mustTime(t, "2024-07-18T10:00:00Z")},
+		},
+		{
+			name: "session override",
+			cfg:  cfg,
+			session: store.InvitationSessionData{
+				StartTime:    validTime(t, "2024-05-20T10:00:00Z"),
+				RSVPOpensAt:  validTime(t, "2024-02-01T00:00:00Z"),
+				RSVPClosesAt: validTime(t, "2024-05-19T00:00:00Z"),
+			},
+			want: Window{OpensAt: mustTime(t, "2024-02-01T00:00:00Z"), ClosesAt: mustTime(t, "2024-05-19T00:00:00Z")},
+		},
+		{name: "unbounded", cfg: Config{}, want: Window{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewPolicy(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewPolicy(): %v", err)
+			}
+			got := policy.Window(tt.session)
+			if !got.OpensAt.Equal(tt.want.OpensAt) || !got.ClosesAt.Equal(tt.want.ClosesAt) {
+				t.Errorf("Window() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyStatus(t *testing.T) {
+	policy, err := NewPolicy(Config{OpenAt: "2024-01-01T00:00:00Z", CloseAt: "2024-06-01T00:00:00Z", CloseDaysBeforeSession: 7})
+	if err != nil {
+		t.Fatalf("NewPolicy(): %v", err)
+	}
+
+	session := store.InvitationSessionData{StartTime: validTime(t, "2024-05-20T10:00:00Z")}
+	tests := []struct {
+		name       string
+		session    store.InvitationSessionData
+		invitation store.InvitationData
+		now        string
+		want       string
+	}{
+		{name: "before open", session: session, now: "2023-12-31T23:59:59Z", want: StatusNotOpen},
+		{name: "at open", session: session, now: "2024-01-01T00:00:00Z", want: StatusOpen},
+		{name: "before close days", session: session, now: "2024-05-13T09:59:59Z", want: StatusOpen},
+		{name: "at close days", session: session, now: "2024-05-13T10:00:00Z", want: StatusClosed},
+		{
+			name:    "session override open",
+			session: store.InvitationSessionData{StartTime: session.StartTime, RSVPClosesAt: validTime(t, "2024-05-19T00:00:00Z")},
+			now:     "2024-05-18T00:00:00Z",
+			want:    StatusOpen,
+		},
+		{
+			name:    "session override not open",
+			session: store.InvitationSessionData{StartTime: session.StartTime, RSVPOpensAt: validTime(t, "2024-03-01T00:00:00Z")},
+			now:     "2024-02-01T00:00:00Z",
+			want:    StatusNotOpen,
+		},
+		{
+			name:       "unlocked",
+			session:    session,
+			invitation: store.InvitationData{RSVPUnlockedUntil: validTime(t, "2024-05-19T00:00:00Z")},
+			now:        "2024-05-18T00:00:00Z",
+			want:       StatusOpen,
+		},
+		{
+			name:       "unlocked before open",
+			session:    session,
+			invitation: store.InvitationData{RSVPUnlockedUntil: validTime(t, "2024-01-02T00:00:00Z")},
+			now:        "2023-12-01T00:00:00Z",
+			want:       StatusOpen,
+		},
+		{
+			name:       "unlock expired",
+			session:    session,
+			invitation: store.InvitationData{RSVPUnlockedUntil: validTime(t, "2024-05-19T00:00:00Z")},
+			now:        "2024-05-19T00:00:00Z",
+			want:       StatusClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := policy.Status(tt.session, tt.invitation, mustTime(t, tt.now))
+			if got != tt.want {
+				t.Errorf("Status() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	policy, err := NewPolicy(Config{OpenAt: "2024-01-01T00:00:00Z", CloseAt: "2024-06-01T00:00:00Z"})
+	if err != nil {
+		t.Fatalf("NewPolicy(): %v", err)
+	}
+
+	if err = policy.Check(mustTime(t, "2024-03-01T00:00:00Z"))(store.InvitationSessionData{}, store.InvitationData{}); err != nil {
+		t.Errorf("Check() inside the window = %v, want nil", err)
+	}
+
+	err = policy.Check(mustTime(t, "2024-06-01T00:00:00Z"))(store.InvitationSessionData{}, store.InvitationData{})
+	var windowErr *WindowError
+	if !errors.As(err, &windowErr) {
+		t.Fatalf("Check() after the window = %v, want *WindowError", err)
+	}
+	if windowErr.Status != StatusClosed || !windowErr.Window.ClosesAt.Equal(mustTime(t, "2024-06-01T00:00:00Z")) {
+		t.Errorf("Check() after the window = %+v, want CLOSED at close_at", windowErr)
+	}
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrAlreadyCheckedIn = errors.New("user has already checked in")
+	ErrDuplicateScan    = errors.New("scan has already been synced")
+)
+
+type CheckInData struct {
+	ID           string
+	UserID       string
+	InvitationID string
+	PeopleCount  int64
+	Gate         string
+	ScannedBy    string
+	DeviceID     string
+	ScanID       string
+	ScannedAt    time.Time
+
+	CreatedAt time.Time
+}
+
+type CheckInQuotaError struct {
+	AllowedPeople   int64
+	CheckedInPeople int64
+	PeopleLeft      int64
+}
+
+func (e *CheckInQuotaError) Error() string {
+	return "check-in exceeds the people allowed for this user"
+}
+
+type CheckInDetailData struct {
+	CheckIn        CheckInData
+	UserName       string
+	InvitationName string
+	SessionID      string
+	SessionName    string
+}
+
+type AttendanceCountData struct {
+	ID             string
+	Name           string
+	MaxSeats       int64
+	ExpectedPeople int64
+	ArrivedPeople  int64
+	DeclinedUsers  int64
+	MaybeUsers     int64
+	MaybePeople    int64
+	PendingUsers   int64
+}
+
+type AttendanceSummaryData struct {
+	Sessions []AttendanceCountData
+	Tags     []AttendanceCountData
+}
+
+type CheckIn interface {
+	Insert(ctx context.Context, checkIn *CheckInData) error
+	FindAllByUserID(ctx context.Context, userID string) ([]*CheckInData, error)
+	FindAllLatest(ctx context.Context, limit int) ([]*CheckInDetailData, error)
+	FindAttendanceSummary(ctx context.Context) (*AttendanceSummaryData, error)
+}
@@ -3,48 +3,125 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 )
 
+var (
+	ErrInvitationInUse   = errors.New("invitation already has registered users")
+	ErrInvitationRevoked = errors.New("invitation has been revoked")
+	ErrInvitationExpired = errors.New("invitation has expired")
+)
+
 const (
 	InvitationStatusAvailable = "AVAILABLE"
 	InvitationStatusUsed      = "USED"
+	InvitationStatusRevoked   = "REVOKED"
+	// InvitationStatusExpired is never stored, it is reported in place of AVAILABLE or USED
+	// once the invitation's expires_at has passed.
+	InvitationStatusExpired = "EXPIRED"
 
 	InvitationTypeSingle = "SINGLE"
 	InvitationTypeGroup  = "GROUP"
 
-	InvitationSession1ID = "ccd52961-fa4e-43ba-a6df-a4c97849d899"
-	InvitationSession2ID = "ccd52961-fa4e-43ba-a6df-a4c97849d898"
+	InvitationGroupDefaultMaxSeats = 10
+
+	InvitationTableNameMaxLength = 50
 )
 
+func DefaultMaxSeats(invitationType string) int64 {
+	if invitationType == InvitationTypeGroup {
+		return InvitationGroupDefaultMaxSeats
+	}
+
+	return 1
+}
+
 type InvitationData struct {
-	ID       string
-	Type     string
-	Name     string
-	Status   string
-	Session  int64
-	Schedule string
+	ID                string
+	Code              string
+	Type              string
+	Name              string
+	Status            string
+	SessionID         string
+	Schedule          string
+	WhatsAppNumber    string
+	MaxSeats          int64
+	ExpiresAt         sql.NullTime
+	TableName         string
+	RSVPUnlockedUntil sql.NullTime
 
 	CreatedAt time.Time
 	UpdatedAt sql.NullTime
 }
 
+type SeatQuotaError struct {
+	MaxSeats      int64
+	ReservedSeats int64
+	SeatsLeft     int64
+}
+
+func (e *SeatQuotaError) Error() string {
+	return fmt.Sprintf("invitation seat quota exceeded: %d of %d seats left", e.SeatsLeft, e.MaxSeats)
+}
+
 type InvitationUserData struct {
 	ID             string
 	Name           string
 	WhatsAppNumber string
+	Attendance     string
 	PeopleCount    int64
 	Status         string
 	QRImage        string
+	Attendees      []UserRSVPAttendeeData
 }
 
 type InvitationCompleteData struct {
 	Invitation InvitationData
-	User       InvitationUserData
+	Users      []InvitationUserData
+	Tags       []TagData
+}
+
+type InvitationFilter struct {
+	Status    string
+	Type      string
+	SessionID string
+	Name      string
+	TagID     string
+
+	SortBy   string
+	SortDesc bool
+
+	Offset int
+	Limit  int
+}
+
+const (
+	InvitationSortByName      = "name"
+	InvitationSortByCreatedAt = "created_at"
+	InvitationSortByStatus    = "status"
+)
+
+type InvitationListData struct {
+	Invitation        InvitationData
+	Users             []InvitationUserData
+	Tags              []TagData
+	RSVPUserCount     int64
+	RSVPPeopleCount   int64
+	DeclinedUserCount int64
 }
 
 type Invitation interface {
 	Insert(ctx context.Context, invitation *InvitationData) error
+	InsertMany(ctx context.Context, invitations []*InvitationData) error
+	Update(ctx context.Context, invitation *InvitationData) ([]*UserRSVPWaitlistDetailData, error)
+	Delete(ctx context.Context, id string, cascade bool) ([]*UserRSVPWaitlistDetailData, error)
 	FindOneByID(ctx context.Context, id string) (*InvitationData, error)
 	FindOneCompleteDataByID(ctx context.Context, id string) (*InvitationCompleteData, error)
+	FindAll(ctx context.Context, filter InvitationFilter) ([]*InvitationListData, error)
+	Count(ctx context.Context, filter InvitationFilter) (int, error)
+	Revoke(ctx context.Context, id string) ([]*UserRSVPWaitlistDetailData, error)
+	Reinstate(ctx context.Context, invitation *InvitationData) error
+	UnlockRSVP(ctx context.Context, invitation *InvitationData) error
 }
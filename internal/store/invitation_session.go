@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvitationSessionInUse = errors.New("invitation session is still used by invitations")
+	ErrInvitationSessionFull  = errors.New("invitation session has no seats left")
+)
+
+type InvitationSessionData struct {
+	ID           string
+	Name         string
+	Schedule     string
+	StartTime    sql.NullTime
+	EndTime      sql.NullTime
+	Venue        string
+	Capacity     sql.NullInt64
+	RSVPOpensAt  sql.NullTime
+	RSVPClosesAt sql.NullTime
+
+	CreatedAt time.Time
+	UpdatedAt sql.NullTime
+}
+
+type InvitationSessionLoadData struct {
+	Session           InvitationSessionData
+	InvitationCount   int64
+	AllocatedSeats    int64
+	UserCount         int64
+	RSVPUserCount     int64
+	DeclinedUserCount int64
+	MaybeUserCount    int64
+	RSVPPeopleCount   int64
+	MaybePeopleCount  int64
+}
+
+func (d InvitationSessionLoadData) SeatsLeft() (int64, bool) {
+	if !d.Session.Capacity.Valid {
+		return 0, false
+	}
+
+	return d.Session.Capacity.Int64 - d.AllocatedSeats, true
+}
+
+type InvitationSession interface {
+	Insert(ctx context.Context, session *InvitationSessionData) error
+	Update(ctx context.Context, session *InvitationSessionData) ([]*UserRSVPWaitlistDetailData, error)
+	Delete(ctx context.Context, id string) error
+	FindAll(ctx context.Context) ([]*InvitationSessionData, error)
+	FindOneByID(ctx context.Context, id string) (*InvitationSessionData, error)
+	FindAllLoad(ctx context.Context) ([]*InvitationSessionLoadData, error)
+}
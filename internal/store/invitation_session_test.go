@@ -0,0 +1,30 @@
+package store
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestInvitationSessionLoadDataSeatsLeft(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity sql.NullInt64
+		seats    int64
+		want     int64
+		wantOK   bool
+	}{
+		{name: "unlimited", seats: 40},
+		{name: "room left", capacity: sql.NullInt64{Int64: 100, Valid: true}, seats: 40, want: 60, wantOK: true},
+		{name: "over allocated", capacity: sql.NullInt64{Int64: 10, Valid: true}, seats: 12, want: -2, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			load := InvitationSessionLoadData{Session: InvitationSessionData{Capacity: tt.capacity}, AllocatedSeats: tt.seats}
+			got, ok := load.SeatsLeft()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("SeatsLeft() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
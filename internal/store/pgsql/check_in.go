@@ -0,0 +1,262 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"be-wedding/internal/store"
+
+	"github.com/google/uuid"
+)
+
+type CheckIn struct {
+	db *sql.DB
+}
+
+func NewCheckIn(db *sql.DB) *CheckIn {
+	return &CheckIn{db: db}
+}
+
+const checkInLockUserQuery = `SELECT u.invitation_id, COALESCE(NULLIF(ursvp.people_count, 0), 1),
+	(SELECT COALESCE(SUM(ci.people_count), 0) FROM check_ins ci WHERE ci.user_id = u.id)
+	FROM users u
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	WHERE u.id = $1
+	FOR UPDATE OF u
+`
+
+const checkInInsertQuery = `INSERT INTO
+check_ins(
+	id, user_id, invitation_id, people_count, gate, scanned_by, device_id, scan_id, scanned_at, created_at
+) values(
+	$1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10
+)
+`
+
+const checkInColumns = `ci.id, ci.user_id, ci.invitation_id, ci.people_count, ci.gate, ci.scanned_by,
+	COALESCE(ci.device_id, ''), COALESCE(ci.scan_id, ''), ci.scanned_at, ci.created_at`
+
+const checkInFindOneByScanQuery = `SELECT ` + checkInColumns + `
+	FROM check_ins ci
+	WHERE ci.device_id = $1 AND ci.scan_id = $2
+`
+
+func scanCheckIn(row interface{ Scan(...interface{}) error }, checkIn *store.CheckInData) error {
+	return row.Scan(
+		&checkIn.ID, &checkIn.UserID, &checkIn.InvitationID, &checkIn.PeopleCount, &checkIn.Gate, &checkIn.ScannedBy,
+		&checkIn.DeviceID, &checkIn.ScanID, &checkIn.ScannedAt, &checkIn.CreatedAt,
+	)
+}
+
+func (s *CheckIn) Insert(ctx context.Context, checkIn *store.CheckInData) error {
+	insertStmt, err := s.db.PrepareContext(ctx, checkInInsertQuery)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var invitationID string
+	var allowedPeople, checkedInPeople int64
+	err = tx.QueryRowContext(ctx, checkInLockUserQuery, checkIn.UserID).Scan(&invitationID, &allowedPeople, &checkedInPeople)
+	if err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	if checkIn.DeviceID != "" && checkIn.ScanID != "" {
+		err = scanCheckIn(tx.QueryRowContext(ctx, checkInFindOneByScanQuery, checkIn.DeviceID, checkIn.ScanID), checkIn)
+		if err == nil {
+			return store.ErrDuplicateScan
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find synced scan: %w", err)
+		}
+	}
+
+	peopleLeft := allowedPeople - checkedInPeople
+	if peopleLeft <= 0 {
+		return store.ErrAlreadyCheckedIn
+	}
+	if checkIn.PeopleCount > peopleLeft {
+		return &store.CheckInQuotaError{
+			AllowedPeople:   allowedPeople,
+			CheckedInPeople: checkedInPeople,
+			PeopleLeft:      peopleLeft,
+		}
+	}
+
+	checkInID := uuid.NewString()
+	createdAt := time.Now().UTC()
+	scannedAt := checkIn.ScannedAt
+	if scannedAt.IsZero() {
+		scannedAt = createdAt
+	}
+	_, err = tx.StmtContext(ctx, insertStmt).ExecContext(ctx,
+		checkInID, checkIn.UserID, invitationID, checkIn.PeopleCount, checkIn.Gate, checkIn.ScannedBy,
+		checkIn.DeviceID, checkIn.ScanID, scannedAt, createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, userUpdateStatusQuery, checkIn.UserID, store.UserStatusCheckedIn, createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	checkIn.ID = checkInID
+	checkIn.InvitationID = invitationID
+	checkIn.ScannedAt = scannedAt
+	checkIn.CreatedAt = createdAt
+
+	return nil
+}
+
+const checkInFindAllByUserIDQuery = `SELECT ` + checkInColumns + `
+	FROM check_ins ci
+	WHERE ci.user_id = $1
+	ORDER BY ci.scanned_at ASC
+`
+
+func (s *CheckIn) FindAllByUserID(ctx context.Context, userID string) ([]*store.CheckInData, error) {
+	checkInList := []*store.CheckInData{}
+
+	rows, err := s.db.QueryContext(ctx, checkInFindAllByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		checkIn := &store.CheckInData{}
+		if err := scanCheckIn(rows, checkIn); err != nil {
+			return nil, err
+		}
+		checkInList = append(checkInList, checkIn)
+	}
+
+	return checkInList, rows.Err()
+}
+
+const checkInFindAllLatestQuery = `SELECT ` + checkInColumns + `,
+	COALESCE(u.name, ''), i.name, s.id, s.session_name
+	FROM check_ins ci
+	JOIN users u
+	ON u.id = ci.user_id
+	JOIN invitations i
+	ON i.id = ci.invitation_id
+	JOIN invitation_sessions s
+	ON s.id = i.session_id
+	ORDER BY ci.scanned_at DESC
+	LIMIT $1
+`
+
+func (s *CheckIn) FindAllLatest(ctx context.Context, limit int) ([]*store.CheckInDetailData, error) {
+	checkInList := []*store.CheckInDetailData{}
+
+	rows, err := s.db.QueryContext(ctx, checkInFindAllLatestQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		detail := &store.CheckInDetailData{}
+		err := rows.Scan(
+			&detail.CheckIn.ID, &detail.CheckIn.UserID, &detail.CheckIn.InvitationID, &detail.CheckIn.PeopleCount,
+			&detail.CheckIn.Gate, &detail.CheckIn.ScannedBy, &detail.CheckIn.DeviceID, &detail.CheckIn.ScanID,
+			&detail.CheckIn.ScannedAt, &detail.CheckIn.CreatedAt,
+			&detail.UserName, &detail.InvitationName, &detail.SessionID, &detail.SessionName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		checkInList = append(checkInList, detail)
+	}
+
+	return checkInList, rows.Err()
+}
+
+const checkInArrivedSubquery = `SELECT invitation_id, SUM(people_count) AS arrived_people
+	FROM check_ins
+	GROUP BY invitation_id`
+
+const checkInSessionAttendanceQuery = `SELECT s.id, s.session_name,
+	COALESCE(SUM(ihc.max_seats), 0), COALESCE(SUM(ihc.rsvp_people_count), 0), COALESCE(SUM(cia.arrived_people), 0),
+	COALESCE(SUM(ihc.declined_user_count), 0), COALESCE(SUM(ihc.maybe_user_count), 0), COALESCE(SUM(ihc.maybe_people_count), 0),
+	COALESCE(SUM(ihc.user_count - ihc.rsvp_user_count), 0)
+	FROM invitation_sessions s
+	LEFT JOIN (` + invitationHeadCountSubquery + `) ihc
+	ON ihc.session_id = s.id
+	LEFT JOIN (` + checkInArrivedSubquery + `) cia
+	ON cia.invitation_id = ihc.invitation_id
+	GROUP BY s.id
+	ORDER BY s.start_time ASC NULLS LAST, s.session_name ASC
+`
+
+const checkInTagAttendanceQuery = `SELECT t.id, t.name,
+	COALESCE(SUM(ihc.max_seats), 0), COALESCE(SUM(ihc.rsvp_people_count), 0), COALESCE(SUM(cia.arrived_people), 0),
+	COALESCE(SUM(ihc.declined_user_count), 0), COALESCE(SUM(ihc.maybe_user_count), 0), COALESCE(SUM(ihc.maybe_people_count), 0),
+	COALESCE(SUM(ihc.user_count - ihc.rsvp_user_count), 0)
+	FROM tags t
+	LEFT JOIN invitation_tags it
+	ON it.tag_id = t.id
+	LEFT JOIN (` + invitationHeadCountSubquery + `) ihc
+	ON ihc.invitation_id = it.invitation_id
+	LEFT JOIN (` + checkInArrivedSubquery + `) cia
+	ON cia.invitation_id = ihc.invitation_id
+	GROUP BY t.id, t.name
+	ORDER BY t.name ASC
+`
+
+func (s *CheckIn) FindAttendanceSummary(ctx context.Context) (*store.AttendanceSummaryData, error) {
+	sessions, err := s.findAllAttendance(ctx, checkInSessionAttendanceQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session attendance: %w", err)
+	}
+
+	tags, err := s.findAllAttendance(ctx, checkInTagAttendanceQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tag attendance: %w", err)
+	}
+
+	return &store.AttendanceSummaryData{
+		Sessions: sessions,
+		Tags:     tags,
+	}, nil
+}
+
+func (s *CheckIn) findAllAttendance(ctx context.Context, query string) ([]store.AttendanceCountData, error) {
+	attendanceList := []store.AttendanceCountData{}
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attendance := store.AttendanceCountData{}
+		err := rows.Scan(
+			&attendance.ID, &attendance.Name,
+			&attendance.MaxSeats, &attendance.ExpectedPeople, &attendance.ArrivedPeople,
+			&attendance.DeclinedUsers, &attendance.MaybeUsers, &attendance.MaybePeople, &attendance.PendingUsers,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attendanceList = append(attendanceList, attendance)
+	}
+
+	return attendanceList, rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"be-wedding/internal/store"
+	"be-wedding/pkg/shortcode"
 
 	"github.com/google/uuid"
 )
@@ -19,14 +20,80 @@ func NewInvitation(db *sql.DB) *Invitation {
 	return &Invitation{db: db}
 }
 
+const invitationStatusColumn = `CASE WHEN i.status <> 'REVOKED' AND i.expires_at <= NOW() THEN 'EXPIRED' ELSE i.status END`
+
+func invitationStatusError(status string) error {
+	switch status {
+	case store.InvitationStatusRevoked:
+		return store.ErrInvitationRevoked
+	case store.InvitationStatusExpired:
+		return store.ErrInvitationExpired
+	}
+
+	return nil
+}
+
+const invitationHeadCountSubquery = `SELECT i.id AS invitation_id, i.session_id, i.type, i.max_seats,
+		COALESCE(SUM(COALESCE(ursvp.people_count, 1)) FILTER (WHERE u.id IS NOT NULL), i.max_seats) AS seats,
+		COUNT(u.id) AS user_count,
+		COUNT(ursvp.id) AS rsvp_user_count,
+		COUNT(ursvp.id) FILTER (WHERE ursvp.attendance = 'NO') AS declined_user_count,
+		COUNT(ursvp.id) FILTER (WHERE ursvp.attendance = 'MAYBE') AS maybe_user_count,
+		COALESCE(SUM(ursvp.people_count), 0) AS rsvp_people_count,
+		COALESCE(SUM(ursvp.people_count) FILTER (WHERE ursvp.attendance = 'MAYBE'), 0) AS maybe_people_count
+	FROM invitations i
+	LEFT JOIN users u
+	ON u.invitation_id = i.id
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	WHERE i.status <> 'REVOKED'
+	GROUP BY i.id, i.session_id, i.type, i.max_seats`
+
+const sessionSeatsQuery = `SELECT COALESCE(SUM(ihc.seats), 0)
+	FROM (` + invitationHeadCountSubquery + `) ihc
+	WHERE ihc.session_id = $1
+`
+
+func findSessionSeats(ctx context.Context, tx *sql.Tx, sessionID string) (int64, error) {
+	var seats int64
+	if err := tx.QueryRowContext(ctx, sessionSeatsQuery, sessionID).Scan(&seats); err != nil {
+		return 0, fmt.Errorf("failed to count session seats: %w", err)
+	}
+
+	return seats, nil
+}
+
 const invitationInsert = `INSERT INTO
 invitations(
-	id, session_id, type, name, status, created_at
+	id, code, session_id, type, name, status, wa_number, max_seats, expires_at, table_name, created_at
 ) values(
-	$1, $2, $3, $4, $5, $6
+	$1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11
 )
+ON CONFLICT (code) DO NOTHING
 `
 
+const invitationCodeAttempts = 5
+
+func execWithUniqueCode(ctx context.Context, stmt *sql.Stmt, args ...interface{}) (string, error) {
+	for attempt := 0; attempt < invitationCodeAttempts; attempt++ {
+		code, err := shortcode.Generate(shortcode.DefaultLength)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate code: %w", err)
+		}
+		args[1] = code
+
+		result, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return "", err
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+			return code, err
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate a unique code after %d attempts", invitationCodeAttempts)
+}
+
 func (s *Invitation) Insert(ctx context.Context, invitation *store.InvitationData) error {
 	insertStmt, err := s.db.PrepareContext(ctx, invitationInsert)
 	if err != nil {
@@ -38,19 +105,13 @@ func (s *Invitation) Insert(ctx context.Context, invitation *store.InvitationDat
 	}
 	defer tx.Rollback()
 
-	var sessionID string
-	if invitation.Session == 1 {
-		sessionID = store.InvitationSession1ID
-	} else {
-		sessionID = store.InvitationSession2ID
-
-	}
 	invitationID := uuid.NewString()
 	createdAt := time.Now().UTC()
 
 	invitationStatus := store.InvitationStatusAvailable
-	_, err = tx.StmtContext(ctx, insertStmt).ExecContext(ctx,
-		invitationID, sessionID, invitation.Type, invitation.Name, invitationStatus, createdAt,
+	invitationCode, err := execWithUniqueCode(ctx, tx.StmtContext(ctx, insertStmt),
+		invitationID, "", invitation.SessionID, invitation.Type, invitation.Name, invitationStatus,
+		invitation.WhatsAppNumber, invitation.MaxSeats, invitation.ExpiresAt, invitation.TableName, createdAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert: %w", err)
@@ -60,14 +121,253 @@ func (s *Invitation) Insert(ctx context.Context, invitation *store.InvitationDat
 		return fmt.Errorf("failed to commit: %w", err)
 	}
 	invitation.ID = invitationID
+	invitation.Code = invitationCode
 	invitation.Status = invitationStatus
 	invitation.CreatedAt = createdAt
 
 	return nil
 }
 
-const invitationFindOneByIDQuery = `SELECT id, type, name, status
-		FROM invitations WHERE id = $1
+func (s *Invitation) InsertMany(ctx context.Context, invitations []*store.InvitationData) error {
+	insertStmt, err := s.db.PrepareContext(ctx, invitationInsert)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	txInsertStmt := tx.StmtContext(ctx, insertStmt)
+	createdAt := time.Now().UTC()
+	invitationIDs := make([]string, len(invitations))
+	invitationCodes := make([]string, len(invitations))
+	for idx, invitation := range invitations {
+		invitationIDs[idx] = uuid.NewString()
+		invitationCodes[idx], err = execWithUniqueCode(ctx, txInsertStmt,
+			invitationIDs[idx], "", invitation.SessionID, invitation.Type, invitation.Name, store.InvitationStatusAvailable,
+			invitation.WhatsAppNumber, invitation.MaxSeats, invitation.ExpiresAt, invitation.TableName, createdAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert invitation %d: %w", idx, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	for idx, invitation := range invitations {
+		invitation.ID = invitationIDs[idx]
+		invitation.Code = invitationCodes[idx]
+		invitation.Status = store.InvitationStatusAvailable
+		invitation.CreatedAt = createdAt
+	}
+
+	return nil
+}
+
+const invitationResolveIDQuery = `SELECT id
+	FROM invitations
+	WHERE id = $1 OR code = UPPER($1)
+	`
+
+func resolveInvitationID(ctx context.Context, db interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}, id string) (string, error) {
+	var invitationID string
+	if err := db.QueryRowContext(ctx, invitationResolveIDQuery, id).Scan(&invitationID); err != nil {
+		return "", err
+	}
+
+	return invitationID, nil
+}
+
+const invitationUpdateQuery = `UPDATE invitations
+	SET session_id = $2, type = $3, name = $4, status = $5, wa_number = $6, max_seats = $7, expires_at = $8, table_name = NULLIF($9, ''), updated_at = $10
+	WHERE id = $1
+	`
+
+const invitationLockStatusQuery = `SELECT status
+	FROM invitations
+	WHERE id = $1
+	FOR UPDATE
+	`
+
+const invitationUpdateLockQuery = `SELECT code, status, max_seats
+	FROM invitations
+	WHERE id = $1
+	FOR UPDATE
+	`
+
+func (s *Invitation) Update(ctx context.Context, invitation *store.InvitationData) ([]*store.UserRSVPWaitlistDetailData, error) {
+	updateStmt, err := s.db.PrepareContext(ctx, invitationUpdateQuery)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if invitation.ID, err = resolveInvitationID(ctx, tx, invitation.ID); err != nil {
+		return nil, err
+	}
+
+	var sessionID string
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockByInvitationIDQuery, invitation.ID).Scan(&sessionID, &capacity); err != nil {
+		return nil, fmt.Errorf("failed to lock session: %w", err)
+	}
+	newCapacity := capacity
+	if invitation.SessionID != sessionID {
+		if err = tx.QueryRowContext(ctx, invitationSessionLockQuery, invitation.SessionID).Scan(&newCapacity); err != nil {
+			return nil, fmt.Errorf("failed to lock session: %w", err)
+		}
+	}
+
+	var status string
+	var maxSeats int64
+	if err = tx.QueryRowContext(ctx, invitationUpdateLockQuery, invitation.ID).Scan(&invitation.Code, &status, &maxSeats); err != nil {
+		return nil, err
+	}
+	if invitation.MaxSeats == 0 {
+		invitation.MaxSeats = maxSeats
+	}
+
+	var reservedSeats int64
+	if err = tx.QueryRowContext(ctx, invitationReservedSeatQuery, invitation.ID, "").Scan(&reservedSeats); err != nil {
+		return nil, fmt.Errorf("failed to count reserved seats: %w", err)
+	}
+	if reservedSeats > invitation.MaxSeats {
+		return nil, &store.SeatQuotaError{MaxSeats: invitation.MaxSeats, ReservedSeats: reservedSeats}
+	}
+
+	seatsBefore, err := findSessionSeats(ctx, tx, invitation.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != store.InvitationStatusRevoked {
+		status = store.InvitationStatusAvailable
+		if reservedSeats >= invitation.MaxSeats {
+			status = store.InvitationStatusUsed
+		}
+	}
+
+	updatedAt := time.Now().UTC()
+	_, err = tx.StmtContext(ctx, updateStmt).ExecContext(ctx,
+		invitation.ID, invitation.SessionID, invitation.Type, invitation.Name, status,
+		invitation.WhatsAppNumber, invitation.MaxSeats, invitation.ExpiresAt, invitation.TableName, updatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update: %w", err)
+	}
+
+	seatsAfter, err := findSessionSeats(ctx, tx, invitation.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if newCapacity.Valid && seatsAfter > seatsBefore && seatsAfter > newCapacity.Int64 {
+		return nil, store.ErrInvitationSessionFull
+	}
+
+	promoted, err := promoteUserRSVPWaitlist(ctx, tx, sessionID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	invitation.Status = status
+	invitation.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+
+	return promoted, nil
+}
+
+const invitationCountUserQuery = `SELECT COUNT(*)
+	FROM users
+	WHERE invitation_id = $1
+	`
+
+var invitationCascadeDeleteQueries = []string{
+	`DELETE FROM user_comment_likes
+	WHERE user_id IN (SELECT id FROM users WHERE invitation_id = $1)
+	OR comment_id IN (SELECT uc.id FROM user_comments uc JOIN users u ON uc.user_id = u.id WHERE u.invitation_id = $1)
+	`,
+	`DELETE FROM user_comments
+	WHERE user_id IN (SELECT id FROM users WHERE invitation_id = $1)
+	`,
+	`DELETE FROM user_rsvps
+	WHERE user_id IN (SELECT id FROM users WHERE invitation_id = $1)
+	`,
+	`DELETE FROM users
+	WHERE invitation_id = $1
+	`,
+}
+
+const invitationDeleteQuery = `DELETE FROM invitations
+	WHERE id = $1
+	`
+
+func (s *Invitation) Delete(ctx context.Context, id string, cascade bool) ([]*store.UserRSVPWaitlistDetailData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if id, err = resolveInvitationID(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	var sessionID string
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockByInvitationIDQuery, id).Scan(&sessionID, &capacity); err != nil {
+		return nil, fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	var status string
+	if err = tx.QueryRowContext(ctx, invitationLockStatusQuery, id).Scan(&status); err != nil {
+		return nil, err
+	}
+
+	var userCount int64
+	if err = tx.QueryRowContext(ctx, invitationCountUserQuery, id).Scan(&userCount); err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+	if userCount > 0 {
+		if !cascade {
+			return nil, store.ErrInvitationInUse
+		}
+		for _, query := range invitationCascadeDeleteQueries {
+			if _, err = tx.ExecContext(ctx, query, id); err != nil {
+				return nil, fmt.Errorf("failed to delete invitation dependents: %w", err)
+			}
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, invitationDeleteQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to delete: %w", err)
+	}
+
+	promoted, err := promoteUserRSVPWaitlist(ctx, tx, sessionID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return promoted, nil
+}
+
+const invitationFindOneByIDQuery = `SELECT i.id, i.code, i.session_id, i.type, i.name, ` + invitationStatusColumn + `, i.wa_number, i.max_seats, i.expires_at,
+		COALESCE(i.table_name, ''), i.rsvp_unlocked_until
+		FROM invitations i WHERE i.id = $1 OR i.code = UPPER($1)
 	`
 
 func (s *Invitation) FindOneByID(ctx context.Context, id string) (*store.InvitationData, error) {
@@ -76,7 +376,9 @@ func (s *Invitation) FindOneByID(ctx context.Context, id string) (*store.Invitat
 	row := s.db.QueryRowContext(ctx, invitationFindOneByIDQuery, id)
 
 	err := row.Scan(
-		&invitation.ID, &invitation.Type, &invitation.Name, &invitation.Status,
+		&invitation.ID, &invitation.Code, &invitation.SessionID, &invitation.Type, &invitation.Name, &invitation.Status,
+		&invitation.WhatsAppNumber, &invitation.MaxSeats, &invitation.ExpiresAt, &invitation.TableName,
+		&invitation.RSVPUnlockedUntil,
 	)
 	if err != nil {
 		return nil, err
@@ -85,16 +387,11 @@ func (s *Invitation) FindOneByID(ctx context.Context, id string) (*store.Invitat
 	return invitation, nil
 }
 
-const invitationFindOneCompleteDataByIDQuery = `SELECT i.id, i.type, i.name, i.status, invs.schedule, COALESCE(u.id, ''), COALESCE(u.name, ''), COALESCE(u.wa_number, ''), COALESCE(u.status, ''), COALESCE(u.qr_image, ''), COALESCE(ursvp.people_count, 0)
+const invitationFindOneCompleteDataByIDQuery = `SELECT i.id, i.code, i.session_id, i.type, i.name, ` + invitationStatusColumn + `, i.max_seats, i.expires_at, COALESCE(i.table_name, ''), i.rsvp_unlocked_until, invs.schedule
 		FROM invitations i
 		LEFT JOIN invitation_sessions invs
 		ON i.session_id = invs.id
-		LEFT JOIN users u
-		ON i.id = u.invitation_id
-		LEFT JOIN user_rsvps ursvp
-		ON u.id = ursvp.user_id
-		WHERE i.id = $1
-		LIMIT 1
+		WHERE i.id = $1 OR i.code = UPPER($1)
 	`
 
 func (s *Invitation) FindOneCompleteDataByID(ctx context.Context, id string) (*store.InvitationCompleteData, error) {
@@ -103,14 +400,477 @@ func (s *Invitation) FindOneCompleteDataByID(ctx context.Context, id string) (*s
 	row := s.db.QueryRowContext(ctx, invitationFindOneCompleteDataByIDQuery, id)
 
 	err := row.Scan(
-		&invitation.Invitation.ID, &invitation.Invitation.Type,
-		&invitation.Invitation.Name, &invitation.Invitation.Status, &invitation.Invitation.Schedule,
-		&invitation.User.ID, &invitation.User.Name, &invitation.User.WhatsAppNumber, &invitation.User.Status,
-		&invitation.User.QRImage, &invitation.User.PeopleCount,
+		&invitation.Invitation.ID, &invitation.Invitation.Code, &invitation.Invitation.SessionID, &invitation.Invitation.Type,
+		&invitation.Invitation.Name, &invitation.Invitation.Status, &invitation.Invitation.MaxSeats, &invitation.Invitation.ExpiresAt,
+		&invitation.Invitation.TableName, &invitation.Invitation.RSVPUnlockedUntil, &invitation.Invitation.Schedule,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	invitation.Users = []store.InvitationUserData{}
+	userRows, err := s.db.QueryContext(ctx, invitationFindAllUserQuery, []string{invitation.Invitation.ID})
+	if err != nil {
+		return nil, err
+	}
+	defer userRows.Close()
+
+	userIDs := []string{}
+	for userRows.Next() {
+		var invitationID string
+		user := store.InvitationUserData{}
+		err := userRows.Scan(
+			&invitationID, &user.ID, &user.Name, &user.WhatsAppNumber, &user.Status, &user.QRImage,
+			&user.Attendance, &user.PeopleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invitation.Users = append(invitation.Users, user)
+		userIDs = append(userIDs, user.ID)
+	}
+	if err = userRows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) != 0 {
+		attendeesByUserID, err := findAllUserRSVPAttendee(ctx, s.db, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for idx := range invitation.Users {
+			invitation.Users[idx].Attendees = attendeesByUserID[invitation.Users[idx].ID]
+		}
+	}
+
+	invitation.Tags = []store.TagData{}
+	tagRows, err := s.db.QueryContext(ctx, invitationFindAllTagQuery, []string{invitation.Invitation.ID})
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var invitationID string
+		tag := store.TagData{}
+		if err := tagRows.Scan(&invitationID, &tag.ID, &tag.Name, &tag.Description); err != nil {
+			return nil, err
+		}
+		invitation.Tags = append(invitation.Tags, tag)
+	}
+	if err = tagRows.Err(); err != nil {
+		return nil, err
+	}
+
 	return invitation, nil
 }
+
+var invitationSortColumns = map[string]string{
+	store.InvitationSortByName:      "i.name",
+	store.InvitationSortByCreatedAt: "i.created_at",
+	store.InvitationSortByStatus:    invitationStatusColumn,
+}
+
+func invitationFilterWhere(filter store.InvitationFilter) (string, []interface{}) {
+	var queryKeys []string
+	var queryParams []interface{}
+
+	if filter.Status != "" {
+		queryKeys = append(queryKeys, "Status")
+		queryParams = append(queryParams, filter.Status)
+	}
+	if filter.Type != "" {
+		queryKeys = append(queryKeys, "Type")
+		queryParams = append(queryParams, filter.Type)
+	}
+	if filter.SessionID != "" {
+		queryKeys = append(queryKeys, "SessionID")
+		queryParams = append(queryParams, filter.SessionID)
+	}
+	if filter.Name != "" {
+		queryKeys = append(queryKeys, "Name")
+		queryParams = append(queryParams, "%"+filter.Name+"%")
+	}
+	if filter.TagID != "" {
+		queryKeys = append(queryKeys, "TagID")
+		queryParams = append(queryParams, filter.TagID)
+	}
+
+	query := ""
+	for index, key := range queryKeys {
+		if index == 0 {
+			query = query + "WHERE "
+		} else {
+			query = query + "AND "
+		}
+
+		switch key {
+		case "Status":
+			query = query + fmt.Sprintf(invitationStatusColumn+` = $%d `, index+1)
+		case "Type":
+			query = query + fmt.Sprintf(`i.type = $%d `, index+1)
+		case "SessionID":
+			query = query + fmt.Sprintf(`i.session_id = $%d `, index+1)
+		case "Name":
+			query = query + fmt.Sprintf(`(i.name ILIKE $%d OR i.code ILIKE $%d) `, index+1, index+1)
+		case "TagID":
+			query = query + fmt.Sprintf(`EXISTS (SELECT 1 FROM invitation_tags it WHERE it.invitation_id = i.id AND it.tag_id = $%d) `, index+1)
+		}
+	}
+
+	return query, queryParams
+}
+
+const invitationCountQuery = `SELECT COUNT(*)
+	FROM invitations i
+	`
+
+func (s *Invitation) Count(ctx context.Context, filter store.InvitationFilter) (int, error) {
+	where, queryParams := invitationFilterWhere(filter)
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, invitationCountQuery+where, queryParams...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+const invitationFindAllQuery = `SELECT i.id, i.code, i.session_id, i.type, i.name, ` + invitationStatusColumn + `, i.wa_number, i.max_seats, i.expires_at,
+	COALESCE(i.table_name, ''), i.rsvp_unlocked_until, COALESCE(invs.schedule, ''), i.created_at, i.updated_at
+	FROM invitations i
+	LEFT JOIN invitation_sessions invs
+	ON i.session_id = invs.id
+	`
+
+const invitationFindAllUserQuery = `SELECT u.invitation_id, u.id, COALESCE(u.name, ''), u.wa_number, u.status, COALESCE(u.qr_image, ''),
+	COALESCE(ursvp.attendance, ''), COALESCE(ursvp.people_count, 0)
+	FROM users u
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	WHERE u.invitation_id = ANY($1)
+	ORDER BY u.created_at ASC
+	`
+
+const invitationFindAllTagQuery = `SELECT it.invitation_id, t.id, t.name, t.description
+	FROM invitation_tags it
+	JOIN tags t
+	ON t.id = it.tag_id
+	WHERE it.invitation_id = ANY($1)
+	ORDER BY t.name ASC
+	`
+
+func (s *Invitation) FindAll(ctx context.Context, filter store.InvitationFilter) ([]*store.InvitationListData, error) {
+	invitationList := []*store.InvitationListData{}
+
+	where, queryParams := invitationFilterWhere(filter)
+	query := invitationFindAllQuery + where
+
+	sortColumn, ok := invitationSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = invitationSortColumns[store.InvitationSortByCreatedAt]
+	}
+	sortDirection := "ASC"
+	if filter.SortDesc {
+		sortDirection = "DESC"
+	}
+	query = query + fmt.Sprintf(`ORDER BY %s %s, i.id ASC `, sortColumn, sortDirection)
+
+	if filter.Limit > 0 {
+		queryParams = append(queryParams, filter.Limit, filter.Offset)
+		query = query + fmt.Sprintf(`LIMIT $%d OFFSET $%d `, len(queryParams)-1, len(queryParams))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitationIDs := []string{}
+	invitationByID := map[string]*store.InvitationListData{}
+	for rows.Next() {
+		invitation := &store.InvitationListData{}
+		err := rows.Scan(
+			&invitation.Invitation.ID, &invitation.Invitation.Code, &invitation.Invitation.SessionID, &invitation.Invitation.Type,
+			&invitation.Invitation.Name, &invitation.Invitation.Status, &invitation.Invitation.WhatsAppNumber,
+			&invitation.Invitation.MaxSeats, &invitation.Invitation.ExpiresAt, &invitation.Invitation.TableName, &invitation.Invitation.RSVPUnlockedUntil, &invitation.Invitation.Schedule, &invitation.Invitation.CreatedAt, &invitation.Invitation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invitation.Users = []store.InvitationUserData{}
+		invitation.Tags = []store.TagData{}
+		invitationList = append(invitationList, invitation)
+		invitationIDs = append(invitationIDs, invitation.Invitation.ID)
+		invitationByID[invitation.Invitation.ID] = invitation
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(invitationIDs) == 0 {
+		return invitationList, nil
+	}
+
+	userRows, err := s.db.QueryContext(ctx, invitationFindAllUserQuery, invitationIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer userRows.Close()
+
+	for userRows.Next() {
+		var invitationID string
+		user := store.InvitationUserData{}
+		err := userRows.Scan(
+			&invitationID, &user.ID, &user.Name, &user.WhatsAppNumber, &user.Status, &user.QRImage,
+			&user.Attendance, &user.PeopleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		invitation := invitationByID[invitationID]
+		invitation.Users = append(invitation.Users, user)
+		if user.Attendance != "" {
+			invitation.RSVPUserCount++
+			invitation.RSVPPeopleCount += user.PeopleCount
+		}
+		if user.Attendance == store.UserRSVPAttendanceNo {
+			invitation.DeclinedUserCount++
+		}
+	}
+
+	if err = userRows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := s.db.QueryContext(ctx, invitationFindAllTagQuery, invitationIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var invitationID string
+		tag := store.TagData{}
+		if err := tagRows.Scan(&invitationID, &tag.ID, &tag.Name, &tag.Description); err != nil {
+			return nil, err
+		}
+		invitation := invitationByID[invitationID]
+		invitation.Tags = append(invitation.Tags, tag)
+	}
+
+	return invitationList, tagRows.Err()
+}
+
+const invitationLockQuery = `SELECT max_seats
+	FROM invitations
+	WHERE id = $1
+	FOR UPDATE
+`
+
+const invitationLockStatusSeatQuery = `SELECT i.max_seats, ` + invitationStatusColumn + `
+	FROM invitations i
+	WHERE i.id = $1
+	FOR UPDATE
+`
+
+const invitationReservedSeatQuery = `SELECT COALESCE(SUM(COALESCE(ursvp.people_count, 1)), 0)
+	FROM users u
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	WHERE u.invitation_id = $1 AND u.id <> $2
+	`
+
+const invitationSeatStatusUpdateQuery = `UPDATE invitations
+	SET status = $2, updated_at = $3
+	WHERE id = $1 AND status IN ('AVAILABLE', 'USED') AND status <> $2
+	`
+
+func reserveInvitationSeats(ctx context.Context, tx *sql.Tx, invitationID string, maxSeats int64, excludeUserID string, requestedSeats int64) error {
+	reservedSeats, err := checkInvitationSeats(ctx, tx, invitationID, maxSeats, excludeUserID, requestedSeats)
+	if err != nil {
+		return err
+	}
+
+	return updateInvitationSeatStatus(ctx, tx, invitationID, reservedSeats+requestedSeats >= maxSeats)
+}
+
+func releaseInvitationSeats(ctx context.Context, tx *sql.Tx, invitationID string, maxSeats int64, userID string, heldSeats int64) error {
+	var reservedSeats int64
+	if err := tx.QueryRowContext(ctx, invitationReservedSeatQuery, invitationID, userID).Scan(&reservedSeats); err != nil {
+		return fmt.Errorf("failed to count reserved seats: %w", err)
+	}
+
+	return updateInvitationSeatStatus(ctx, tx, invitationID, reservedSeats+heldSeats >= maxSeats)
+}
+
+func updateInvitationSeatStatus(ctx context.Context, tx *sql.Tx, invitationID string, full bool) error {
+	status := store.InvitationStatusAvailable
+	if full {
+		status = store.InvitationStatusUsed
+	}
+	_, err := tx.ExecContext(ctx, invitationSeatStatusUpdateQuery, invitationID, status, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to update invitation status: %w", err)
+	}
+
+	return nil
+}
+
+func checkInvitationSeats(ctx context.Context, tx *sql.Tx, invitationID string, maxSeats int64, excludeUserID string, requestedSeats int64) (int64, error) {
+	var reservedSeats int64
+	if err := tx.QueryRowContext(ctx, invitationReservedSeatQuery, invitationID, excludeUserID).Scan(&reservedSeats); err != nil {
+		return 0, fmt.Errorf("failed to count reserved seats: %w", err)
+	}
+
+	if reservedSeats+requestedSeats > maxSeats {
+		seatsLeft := maxSeats - reservedSeats
+		if seatsLeft < 0 {
+			seatsLeft = 0
+		}
+		return 0, &store.SeatQuotaError{MaxSeats: maxSeats, ReservedSeats: reservedSeats, SeatsLeft: seatsLeft}
+	}
+
+	return reservedSeats, nil
+}
+
+const invitationRevokeQuery = `UPDATE invitations
+	SET status = $2, updated_at = $3
+	WHERE id = $1
+	`
+
+const invitationSessionLockByInvitationIDQuery = `SELECT s.id, s.capacity
+	FROM invitation_sessions s
+	JOIN invitations i
+	ON i.session_id = s.id
+	WHERE i.id = $1
+	FOR UPDATE OF s
+	`
+
+func (s *Invitation) Revoke(ctx context.Context, id string) ([]*store.UserRSVPWaitlistDetailData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if id, err = resolveInvitationID(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	var sessionID string
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockByInvitationIDQuery, id).Scan(&sessionID, &capacity); err != nil {
+		return nil, fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, invitationRevokeQuery, id, store.InvitationStatusRevoked, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	promoted, err := promoteUserRSVPWaitlist(ctx, tx, sessionID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return promoted, nil
+}
+
+const invitationReinstateQuery = `UPDATE invitations
+	SET status = $2, expires_at = $3, updated_at = $4
+	WHERE id = $1
+	`
+
+func (s *Invitation) Reinstate(ctx context.Context, invitation *store.InvitationData) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if invitation.ID, err = resolveInvitationID(ctx, tx, invitation.ID); err != nil {
+		return err
+	}
+
+	var sessionID string
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockByInvitationIDQuery, invitation.ID).Scan(&sessionID, &capacity); err != nil {
+		return fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	var maxSeats int64
+	if err = tx.QueryRowContext(ctx, invitationLockQuery, invitation.ID).Scan(&maxSeats); err != nil {
+		return err
+	}
+
+	seatsBefore, err := findSessionSeats(ctx, tx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	var reservedSeats int64
+	if err = tx.QueryRowContext(ctx, invitationReservedSeatQuery, invitation.ID, "").Scan(&reservedSeats); err != nil {
+		return fmt.Errorf("failed to count reserved seats: %w", err)
+	}
+
+	status := store.InvitationStatusAvailable
+	if reservedSeats >= maxSeats {
+		status = store.InvitationStatusUsed
+	}
+	updatedAt := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, invitationReinstateQuery, invitation.ID, status, invitation.ExpiresAt, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to reinstate: %w", err)
+	}
+
+	seatsAfter, err := findSessionSeats(ctx, tx, sessionID)
+	if err != nil {
+		return err
+	}
+	if capacity.Valid && seatsAfter > seatsBefore && seatsAfter > capacity.Int64 {
+		return store.ErrInvitationSessionFull
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	invitation.Status = status
+	invitation.MaxSeats = maxSeats
+	invitation.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+
+	return nil
+}
+
+const invitationUnlockRSVPQuery = `UPDATE invitations
+	SET rsvp_unlocked_until = $2, updated_at = $3
+	WHERE id = $1
+	`
+
+func (s *Invitation) UnlockRSVP(ctx context.Context, invitation *store.InvitationData) error {
+	var err error
+	if invitation.ID, err = resolveInvitationID(ctx, s.db, invitation.ID); err != nil {
+		return err
+	}
+
+	updatedAt := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, invitationUnlockRSVPQuery, invitation.ID, invitation.RSVPUnlockedUntil, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to unlock rsvp: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	invitation.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+
+	return nil
+}
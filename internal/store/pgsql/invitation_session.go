@@ -0,0 +1,232 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"be-wedding/internal/store"
+
+	"github.com/google/uuid"
+)
+
+type InvitationSession struct {
+	db *sql.DB
+}
+
+func NewInvitationSession(db *sql.DB) *InvitationSession {
+	return &InvitationSession{db: db}
+}
+
+const invitationSessionInsertQuery = `INSERT INTO
+invitation_sessions(
+	id, session_name, schedule, start_time, end_time, venue, capacity, rsvp_opens_at, rsvp_closes_at, created_at
+) values(
+	$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+`
+
+func (s *InvitationSession) Insert(ctx context.Context, session *store.InvitationSessionData) error {
+	insertStmt, err := s.db.PrepareContext(ctx, invitationSessionInsertQuery)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	sessionID := uuid.NewString()
+	createdAt := time.Now().UTC()
+	_, err = tx.StmtContext(ctx, insertStmt).ExecContext(ctx,
+		sessionID, session.Name, session.Schedule, session.StartTime, session.EndTime, session.Venue, session.Capacity,
+		session.RSVPOpensAt, session.RSVPClosesAt, createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	session.ID = sessionID
+	session.CreatedAt = createdAt
+
+	return nil
+}
+
+const invitationSessionUpdateQuery = `UPDATE invitation_sessions
+	SET session_name = $2, schedule = $3, start_time = $4, end_time = $5, venue = $6, capacity = $7,
+	rsvp_opens_at = $8, rsvp_closes_at = $9, updated_at = $10
+	WHERE id = $1
+`
+
+func (s *InvitationSession) Update(ctx context.Context, session *store.InvitationSessionData) ([]*store.UserRSVPWaitlistDetailData, error) {
+	updateStmt, err := s.db.PrepareContext(ctx, invitationSessionUpdateQuery)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockQuery, session.ID).Scan(&capacity); err != nil {
+		return nil, err
+	}
+
+	updatedAt := time.Now().UTC()
+	_, err = tx.StmtContext(ctx, updateStmt).ExecContext(ctx,
+		session.ID, session.Name, session.Schedule, session.StartTime, session.EndTime, session.Venue, session.Capacity,
+		session.RSVPOpensAt, session.RSVPClosesAt, updatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update: %w", err)
+	}
+
+	promoted, err := promoteUserRSVPWaitlist(ctx, tx, session.ID, session.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	session.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+
+	return promoted, nil
+}
+
+const invitationSessionCountInvitationQuery = `SELECT COUNT(*)
+	FROM invitations
+	WHERE session_id = $1
+`
+
+const invitationSessionDeleteQuery = `DELETE FROM invitation_sessions
+	WHERE id = $1
+`
+
+func (s *InvitationSession) Delete(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var invitationCount int64
+	if err = tx.QueryRowContext(ctx, invitationSessionCountInvitationQuery, id).Scan(&invitationCount); err != nil {
+		return fmt.Errorf("failed to count invitations: %w", err)
+	}
+	if invitationCount > 0 {
+		return store.ErrInvitationSessionInUse
+	}
+
+	result, err := tx.ExecContext(ctx, invitationSessionDeleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+const invitationSessionFindAllQuery = `SELECT id, session_name, schedule, start_time, end_time, venue, capacity,
+	rsvp_opens_at, rsvp_closes_at, created_at, updated_at
+	FROM invitation_sessions
+	ORDER BY start_time ASC NULLS LAST, session_name ASC
+`
+
+func (s *InvitationSession) FindAll(ctx context.Context) ([]*store.InvitationSessionData, error) {
+	sessionList := []*store.InvitationSessionData{}
+
+	rows, err := s.db.QueryContext(ctx, invitationSessionFindAllQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		session := &store.InvitationSessionData{}
+		err := rows.Scan(
+			&session.ID, &session.Name, &session.Schedule, &session.StartTime, &session.EndTime,
+			&session.Venue, &session.Capacity, &session.RSVPOpensAt, &session.RSVPClosesAt, &session.CreatedAt, &session.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sessionList = append(sessionList, session)
+	}
+
+	return sessionList, nil
+}
+
+const invitationSessionFindOneByIDQuery = `SELECT id, session_name, schedule, start_time, end_time, venue, capacity,
+	rsvp_opens_at, rsvp_closes_at, created_at, updated_at
+	FROM invitation_sessions
+	WHERE id = $1
+`
+
+func (s *InvitationSession) FindOneByID(ctx context.Context, id string) (*store.InvitationSessionData, error) {
+	session := &store.InvitationSessionData{}
+
+	row := s.db.QueryRowContext(ctx, invitationSessionFindOneByIDQuery, id)
+
+	err := row.Scan(
+		&session.ID, &session.Name, &session.Schedule, &session.StartTime, &session.EndTime,
+		&session.Venue, &session.Capacity, &session.RSVPOpensAt, &session.RSVPClosesAt, &session.CreatedAt, &session.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+const invitationSessionFindAllLoadQuery = `SELECT s.id, s.session_name, s.schedule, s.start_time, s.end_time, s.venue, s.capacity,
+	s.rsvp_opens_at, s.rsvp_closes_at, s.created_at, s.updated_at,
+	COUNT(ihc.invitation_id), COALESCE(SUM(ihc.seats), 0),
+	COALESCE(SUM(ihc.user_count), 0), COALESCE(SUM(ihc.rsvp_user_count), 0), COALESCE(SUM(ihc.declined_user_count), 0),
+	COALESCE(SUM(ihc.maybe_user_count), 0), COALESCE(SUM(ihc.rsvp_people_count), 0), COALESCE(SUM(ihc.maybe_people_count), 0)
+	FROM invitation_sessions s
+	LEFT JOIN (` + invitationHeadCountSubquery + `) ihc
+	ON ihc.session_id = s.id
+	GROUP BY s.id
+	ORDER BY s.start_time ASC NULLS LAST, s.session_name ASC
+`
+
+func (s *InvitationSession) FindAllLoad(ctx context.Context) ([]*store.InvitationSessionLoadData, error) {
+	loadList := []*store.InvitationSessionLoadData{}
+
+	rows, err := s.db.QueryContext(ctx, invitationSessionFindAllLoadQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		load := &store.InvitationSessionLoadData{}
+		err := rows.Scan(
+			&load.Session.ID, &load.Session.Name, &load.Session.Schedule, &load.Session.StartTime, &load.Session.EndTime,
+			&load.Session.Venue, &load.Session.Capacity, &load.Session.RSVPOpensAt, &load.Session.RSVPClosesAt,
+			&load.Session.CreatedAt, &load.Session.UpdatedAt,
+			&load.InvitationCount, &load.AllocatedSeats, &load.UserCount, &load.RSVPUserCount, &load.DeclinedUserCount,
+			&load.MaybeUserCount, &load.RSVPPeopleCount, &load.MaybePeopleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		loadList = append(loadList, load)
+	}
+
+	return loadList, rows.Err()
+}
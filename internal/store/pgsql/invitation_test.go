@@ -0,0 +1,52 @@
+package pgsql
+
+import (
+	"reflect"
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func TestInvitationFilterWhere(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     store.InvitationFilter
+		wantWhere  string
+		wantParams []interface{}
+	}{
+		{
+			name:   "no filter",
+			filter: store.InvitationFilter{},
+		},
+		{
+			name:       "type and name",
+			filter:     store.InvitationFilter{Type: store.InvitationTypeGroup, Name: "budi"},
+			wantWhere:  "WHERE i.type = $1 AND (i.name ILIKE $2 OR i.code ILIKE $2) ",
+			wantParams: []interface{}{store.InvitationTypeGroup, "%budi%"},
+		},
+		{
+			name:       "session and tag",
+			filter:     store.InvitationFilter{SessionID: "s1", TagID: "t1"},
+			wantWhere:  "WHERE i.session_id = $1 AND EXISTS (SELECT 1 FROM invitation_tags it WHERE it.invitation_id = i.id AND it.tag_id = $2) ",
+			wantParams: []interface{}{"s1", "t1"},
+		},
+		{
+			name:       "status",
+			filter:     store.InvitationFilter{Status: store.InvitationStatusExpired},
+			wantWhere:  "WHERE " + invitationStatusColumn + " = $1 ",
+			wantParams: []interface{}{store.InvitationStatusExpired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, params := invitationFilterWhere(tt.filter)
+			if where != tt.wantWhere {
+				t.Errorf("where = %q, want %q", where, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"be-wedding/internal/store"
+
+	"github.com/google/uuid"
+)
+
+type PlusOne struct {
+	db *sql.DB
+}
+
+func NewPlusOne(db *sql.DB) *PlusOne {
+	return &PlusOne{db: db}
+}
+
+const plusOneRequestDetailColumns = `por.id, por.invitation_id, por.user_id, por.name, por.status, por.decided_by, por.decision_note,
+	por.created_at, por.decided_at, COALESCE(u.name, ''), u.wa_number, i.name, i.type, i.max_seats`
+
+const plusOneRequestDetailJoins = `FROM plus_one_requests por
+	JOIN users u
+	ON u.id = por.user_id
+	JOIN invitations i
+	ON i.id = por.invitation_id`
+
+func scanPlusOneRequestDetail(row interface{ Scan(...interface{}) error }) (*store.PlusOneRequestDetailData, error) {
+	detail := &store.PlusOneRequestDetailData{}
+	err := row.Scan(
+		&detail.Request.ID, &detail.Request.InvitationID, &detail.Request.UserID, &detail.Request.Name,
+		&detail.Request.Status, &detail.Request.DecidedBy, &detail.Request.DecisionNote,
+		&detail.Request.CreatedAt, &detail.Request.DecidedAt,
+		&detail.UserName, &detail.WhatsAppNumber, &detail.InvitationName, &detail.InvitationType, &detail.MaxSeats,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+const plusOneUserInvitationLockQuery = `SELECT i.id, i.type, i.status, EXISTS (
+		SELECT 1 FROM plus_one_requests por WHERE por.invitation_id = i.id AND por.status = 'APPROVED'
+	)
+	FROM users u
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	WHERE u.id = $1
+	FOR UPDATE OF i
+	`
+
+const plusOneRequestInsertQuery = `INSERT INTO
+plus_one_requests(
+	id, invitation_id, user_id, name, status, created_at
+) values(
+	$1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id) WHERE status = 'PENDING' DO NOTHING
+`
+
+func (s *PlusOne) Insert(ctx context.Context, request *store.PlusOneRequestData) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var invitationID, invitationType, invitationStatus string
+	var approved bool
+	err = tx.QueryRowContext(ctx, plusOneUserInvitationLockQuery, request.UserID).Scan(&invitationID, &invitationType, &invitationStatus, &approved)
+	if err != nil {
+		return err
+	}
+	if invitationType != store.InvitationTypeSingle || invitationStatus == store.InvitationStatusRevoked || approved {
+		return store.ErrPlusOneNotAllowed
+	}
+
+	requestID := uuid.NewString()
+	createdAt := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, plusOneRequestInsertQuery,
+		requestID, invitationID, request.UserID, request.Name, store.PlusOneStatusPending, createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return store.ErrPlusOnePending
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	request.ID = requestID
+	request.InvitationID = invitationID
+	request.Status = store.PlusOneStatusPending
+	request.CreatedAt = createdAt
+
+	return nil
+}
+
+const plusOneRequestFindAllQuery = `SELECT ` + plusOneRequestDetailColumns + `
+	` + plusOneRequestDetailJoins + `
+	WHERE 1 = 1
+	`
+
+func (s *PlusOne) FindAll(ctx context.Context, filter store.PlusOneRequestFilter) ([]*store.PlusOneRequestDetailData, error) {
+	query := plusOneRequestFindAllQuery
+	queryParams := []interface{}{}
+	if filter.UserID != "" {
+		queryParams = append(queryParams, filter.UserID)
+		query += fmt.Sprintf("AND por.user_id = $%d ", len(queryParams))
+	}
+	if filter.Status != "" {
+		queryParams = append(queryParams, filter.Status)
+		query += fmt.Sprintf("AND por.status = $%d ", len(queryParams))
+	}
+	query += "ORDER BY por.created_at, por.id"
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	requestList := []*store.PlusOneRequestDetailData{}
+	for rows.Next() {
+		detail, err := scanPlusOneRequestDetail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		requestList = append(requestList, detail)
+	}
+
+	return requestList, rows.Err()
+}
+
+const plusOneRequestInvitationQuery = `SELECT invitation_id
+	FROM plus_one_requests
+	WHERE id = $1
+	`
+
+const plusOneRequestLockQuery = `SELECT status
+	FROM plus_one_requests
+	WHERE id = $1
+	FOR UPDATE
+	`
+
+const plusOneRequestDecideQuery = `UPDATE plus_one_requests
+	SET status = $2, decided_by = $3, decision_note = $4, decided_at = $5
+	WHERE id = $1
+	`
+
+const plusOneInvitationAddSeatQuery = `UPDATE invitations
+	SET max_seats = max_seats + 1, updated_at = $2
+	WHERE id = $1
+	`
+
+const plusOneRequestFindOneQuery = `SELECT ` + plusOneRequestDetailColumns + `
+	` + plusOneRequestDetailJoins + `
+	WHERE por.id = $1
+	`
+
+func (s *PlusOne) Decide(ctx context.Context, request *store.PlusOneRequestData) (*store.PlusOneRequestDetailData, []*store.UserRSVPWaitlistDetailData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var invitationID string
+	if err = tx.QueryRowContext(ctx, plusOneRequestInvitationQuery, request.ID).Scan(&invitationID); err != nil {
+		return nil, nil, err
+	}
+
+	var sessionID string
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockByInvitationIDQuery, invitationID).Scan(&sessionID, &capacity); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	var maxSeats int64
+	var invitationStatus string
+	if err = tx.QueryRowContext(ctx, invitationLockStatusSeatQuery, invitationID).Scan(&maxSeats, &invitationStatus); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock invitation: %w", err)
+	}
+	if invitationStatus == store.InvitationStatusRevoked {
+		return nil, nil, store.ErrPlusOneNotAllowed
+	}
+
+	var status string
+	if err = tx.QueryRowContext(ctx, plusOneRequestLockQuery, request.ID).Scan(&status); err != nil {
+		return nil, nil, err
+	}
+	if status != store.PlusOneStatusPending {
+		return nil, nil, store.ErrPlusOneDecided
+	}
+
+	promoted := []*store.UserRSVPWaitlistDetailData{}
+	decidedAt := time.Now().UTC()
+	if request.Status == store.PlusOneStatusApproved {
+		if capacity.Valid {
+			seats, err := findSessionSeats(ctx, tx, sessionID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if seats+1 > capacity.Int64 {
+				return nil, nil, store.ErrPlusOneNoSeat
+			}
+		}
+
+		if _, err = tx.ExecContext(ctx, plusOneInvitationAddSeatQuery, invitationID, decidedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to add invitation seat: %w", err)
+		}
+		if err = reserveInvitationSeats(ctx, tx, invitationID, maxSeats+1, "", 0); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, plusOneRequestDecideQuery,
+		request.ID, request.Status, request.DecidedBy, request.DecisionNote, decidedAt,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decide: %w", err)
+	}
+
+	if request.Status == store.PlusOneStatusApproved {
+		if promoted, err = promoteUserRSVPWaitlist(ctx, tx, sessionID, capacity); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	detail, err := scanPlusOneRequestDetail(tx.QueryRowContext(ctx, plusOneRequestFindOneQuery, request.ID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find decided request: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return detail, promoted, nil
+}
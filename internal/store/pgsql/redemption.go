@@ -0,0 +1,361 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"be-wedding/internal/store"
+
+	"github.com/google/uuid"
+)
+
+type Redemption struct {
+	db *sql.DB
+}
+
+func NewRedemption(db *sql.DB) *Redemption {
+	return &Redemption{db: db}
+}
+
+const redemptionInvitationPeopleSubquery = `SELECT u.invitation_id, SUM(COALESCE(ursvp.people_count, 1)) AS people_count
+	FROM users u
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	WHERE i.status <> 'REVOKED'
+	GROUP BY u.invitation_id`
+
+const redemptionAllowanceColumn = `CASE WHEN ip.invitation_id IS NULL THEN 0
+	WHEN ri.allowance_type = 'PER_INVITATION' THEN ri.allowance
+	ELSE ri.allowance * ip.people_count END`
+
+const redemptionItemColumns = `ri.id, ri.name, ri.description, ri.allowance_type, ri.allowance, ri.stock, ri.created_at, ri.updated_at`
+
+func scanRedemptionItem(row interface{ Scan(...interface{}) error }, item *store.RedemptionItemData, dest ...interface{}) error {
+	return row.Scan(append([]interface{}{
+		&item.ID, &item.Name, &item.Description, &item.AllowanceType, &item.Allowance, &item.Stock,
+		&item.CreatedAt, &item.UpdatedAt,
+	}, dest...)...)
+}
+
+const redemptionItemInsertQuery = `INSERT INTO
+redemption_items(
+	id, name, description, allowance_type, allowance, stock, created_at
+) values(
+	$1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT DO NOTHING
+`
+
+func (s *Redemption) InsertItem(ctx context.Context, item *store.RedemptionItemData) error {
+	itemID := uuid.NewString()
+	createdAt := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, redemptionItemInsertQuery,
+		itemID, item.Name, item.Description, item.AllowanceType, item.Allowance, item.Stock, createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return store.ErrRedemptionItemNameTaken
+	}
+
+	item.ID = itemID
+	item.CreatedAt = createdAt
+
+	return nil
+}
+
+const redemptionItemLockQuery = `SELECT id
+	FROM redemption_items
+	WHERE id = $1
+	FOR UPDATE
+`
+
+const redemptionItemNameTakenQuery = `SELECT EXISTS (
+	SELECT 1 FROM redemption_items WHERE LOWER(name) = LOWER($1) AND id <> $2
+)
+`
+
+const redemptionItemRedeemedQuery = `SELECT COALESCE(SUM(quantity), 0)
+	FROM redemptions
+	WHERE item_id = $1
+`
+
+const redemptionItemUpdateQuery = `UPDATE redemption_items
+	SET name = $2, description = $3, allowance_type = $4, allowance = $5, stock = $6, updated_at = $7
+	WHERE id = $1
+`
+
+func (s *Redemption) UpdateItem(ctx context.Context, item *store.RedemptionItemData) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = tx.QueryRowContext(ctx, redemptionItemLockQuery, item.ID).Scan(&item.ID); err != nil {
+		return err
+	}
+
+	var nameTaken bool
+	if err = tx.QueryRowContext(ctx, redemptionItemNameTakenQuery, item.Name, item.ID).Scan(&nameTaken); err != nil {
+		return fmt.Errorf("failed to check item name: %w", err)
+	}
+	if nameTaken {
+		return store.ErrRedemptionItemNameTaken
+	}
+
+	var redeemed int64
+	if err = tx.QueryRowContext(ctx, redemptionItemRedeemedQuery, item.ID).Scan(&redeemed); err != nil {
+		return fmt.Errorf("failed to count redeemed quantity: %w", err)
+	}
+	if item.Stock.Valid && item.Stock.Int64 < redeemed {
+		return &store.RedemptionStockError{Stock: item.Stock.Int64, Redeemed: redeemed}
+	}
+
+	updatedAt := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, redemptionItemUpdateQuery,
+		item.ID, item.Name, item.Description, item.AllowanceType, item.Allowance, item.Stock, updatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	item.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+
+	return nil
+}
+
+const redemptionItemDeleteQuery = `DELETE FROM redemption_items
+	WHERE id = $1
+`
+
+func (s *Redemption) DeleteItem(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = tx.QueryRowContext(ctx, redemptionItemLockQuery, id).Scan(&id); err != nil {
+		return err
+	}
+
+	var redeemed int64
+	if err = tx.QueryRowContext(ctx, redemptionItemRedeemedQuery, id).Scan(&redeemed); err != nil {
+		return fmt.Errorf("failed to count redeemed quantity: %w", err)
+	}
+	if redeemed > 0 {
+		return store.ErrRedemptionItemInUse
+	}
+
+	if _, err = tx.ExecContext(ctx, redemptionItemDeleteQuery, id); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+const redemptionItemFindAllQuery = `SELECT ` + redemptionItemColumns + `
+	FROM redemption_items ri
+	`
+
+func (s *Redemption) findAllItem(ctx context.Context, query string, args ...interface{}) ([]*store.RedemptionItemData, error) {
+	itemList := []*store.RedemptionItemData{}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &store.RedemptionItemData{}
+		if err := scanRedemptionItem(rows, item); err != nil {
+			return nil, err
+		}
+		itemList = append(itemList, item)
+	}
+
+	return itemList, rows.Err()
+}
+
+func (s *Redemption) FindAllItem(ctx context.Context) ([]*store.RedemptionItemData, error) {
+	return s.findAllItem(ctx, redemptionItemFindAllQuery+`ORDER BY ri.name ASC`)
+}
+
+func (s *Redemption) FindOneItemByID(ctx context.Context, id string) (*store.RedemptionItemData, error) {
+	itemList, err := s.findAllItem(ctx, redemptionItemFindAllQuery+`WHERE ri.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(itemList) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return itemList[0], nil
+}
+
+const redemptionFindAllAllowanceQuery = `SELECT ` + redemptionItemColumns + `,
+	` + redemptionAllowanceColumn + `,
+	COALESCE((SELECT SUM(r.quantity) FROM redemptions r WHERE r.item_id = ri.id AND r.invitation_id = $1), 0),
+	COALESCE((SELECT SUM(r.quantity) FROM redemptions r WHERE r.item_id = ri.id), 0)
+	FROM redemption_items ri
+	LEFT JOIN (` + redemptionInvitationPeopleSubquery + `) ip
+	ON ip.invitation_id = $1
+	`
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func findAllAllowance(ctx context.Context, db querier, query string, args ...interface{}) ([]*store.RedemptionAllowanceData, error) {
+	allowanceList := []*store.RedemptionAllowanceData{}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		allowance := &store.RedemptionAllowanceData{}
+		err := scanRedemptionItem(rows, &allowance.Item, &allowance.Allowance, &allowance.Redeemed, &allowance.TotalRedeemed)
+		if err != nil {
+			return nil, err
+		}
+		allowanceList = append(allowanceList, allowance)
+	}
+
+	return allowanceList, rows.Err()
+}
+
+func (s *Redemption) FindAllAllowanceByInvitationID(ctx context.Context, invitationID string) ([]*store.RedemptionAllowanceData, error) {
+	return findAllAllowance(ctx, s.db, redemptionFindAllAllowanceQuery+`ORDER BY ri.name ASC`, invitationID)
+}
+
+const redemptionUserInvitationQuery = `SELECT i.id, i.status
+	FROM users u
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	WHERE u.id = $1
+	FOR SHARE OF i
+`
+
+const redemptionInsertQuery = `INSERT INTO
+redemptions(
+	id, item_id, invitation_id, user_id, quantity, redeemed_by, created_at
+) values(
+	$1, $2, $3, $4, $5, $6, $7
+)
+`
+
+func (s *Redemption) Insert(ctx context.Context, redemption *store.RedemptionData) error {
+	insertStmt, err := s.db.PrepareContext(ctx, redemptionInsertQuery)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var itemID string
+	if err = tx.QueryRowContext(ctx, redemptionItemLockQuery, redemption.ItemID).Scan(&itemID); err != nil {
+		return err
+	}
+
+	var invitationID, invitationStatus string
+	err = tx.QueryRowContext(ctx, redemptionUserInvitationQuery, redemption.UserID).Scan(&invitationID, &invitationStatus)
+	if err != nil {
+		return fmt.Errorf("failed to find user invitation: %w", err)
+	}
+	if invitationStatus == store.InvitationStatusRevoked {
+		return store.ErrInvitationRevoked
+	}
+
+	allowanceList, err := findAllAllowance(ctx, tx, redemptionFindAllAllowanceQuery+`WHERE ri.id = $2`, invitationID, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to find allowance: %w", err)
+	}
+	if len(allowanceList) == 0 {
+		return sql.ErrNoRows
+	}
+	allowance := allowanceList[0]
+
+	left := allowance.Left()
+	if redemption.Quantity == 0 {
+		redemption.Quantity = left
+	}
+	if left == 0 || redemption.Quantity > left {
+		return &store.RedemptionQuotaError{
+			Allowance: allowance.Allowance,
+			Redeemed:  allowance.Redeemed,
+			Left:      left,
+		}
+	}
+	if stockLeft, ok := allowance.StockLeft(); ok && redemption.Quantity > stockLeft {
+		return &store.RedemptionStockError{Stock: allowance.Item.Stock.Int64, Redeemed: allowance.TotalRedeemed}
+	}
+
+	redemptionID := uuid.NewString()
+	createdAt := time.Now().UTC()
+	_, err = tx.StmtContext(ctx, insertStmt).ExecContext(ctx,
+		redemptionID, itemID, invitationID, redemption.UserID, redemption.Quantity, redemption.RedeemedBy, createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	redemption.ID = redemptionID
+	redemption.InvitationID = invitationID
+	redemption.CreatedAt = createdAt
+
+	return nil
+}
+
+const redemptionFindAllStockQuery = `SELECT ` + redemptionItemColumns + `,
+	COUNT(ip.invitation_id), COALESCE(SUM(CASE WHEN ip.invitation_id IS NOT NULL THEN ` + redemptionAllowanceColumn + ` END), 0),
+	COALESCE((SELECT SUM(r.quantity) FROM redemptions r WHERE r.item_id = ri.id), 0)
+	FROM redemption_items ri
+	LEFT JOIN (` + redemptionInvitationPeopleSubquery + `) ip
+	ON TRUE
+	GROUP BY ri.id
+	ORDER BY ri.name ASC
+`
+
+func (s *Redemption) FindAllStock(ctx context.Context) ([]*store.RedemptionStockData, error) {
+	stockList := []*store.RedemptionStockData{}
+
+	rows, err := s.db.QueryContext(ctx, redemptionFindAllStockQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stock := &store.RedemptionStockData{}
+		err := scanRedemptionItem(rows, &stock.Item, &stock.InvitationCount, &stock.TotalAllowance, &stock.TotalRedeemed)
+		if err != nil {
+			return nil, err
+		}
+		stockList = append(stockList, stock)
+	}
+
+	return stockList, rows.Err()
+}
@@ -0,0 +1,185 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"be-wedding/internal/store"
+)
+
+type Report struct {
+	db *sql.DB
+}
+
+func NewReport(db *sql.DB) *Report {
+	return &Report{db: db}
+}
+
+const reportHeadCountColumns = `COUNT(ihc.invitation_id), COALESCE(SUM(ihc.max_seats), 0),
+	COALESCE(SUM(ihc.user_count), 0), COALESCE(SUM(ihc.rsvp_user_count), 0),
+	COALESCE(SUM(ihc.declined_user_count), 0), COALESCE(SUM(ihc.maybe_user_count), 0),
+	COALESCE(SUM(ihc.rsvp_people_count), 0), COALESCE(SUM(ihc.maybe_people_count), 0),
+	COUNT(ihc.invitation_id) FILTER (WHERE ihc.user_count = 0)`
+
+const reportSessionHeadCountQuery = `SELECT s.id, s.session_name, ` + reportHeadCountColumns + `
+	FROM invitation_sessions s
+	LEFT JOIN (` + invitationHeadCountSubquery + `) ihc
+	ON ihc.session_id = s.id
+	GROUP BY s.id
+	ORDER BY s.start_time ASC NULLS LAST, s.session_name ASC
+`
+
+const reportInvitationTypeHeadCountQuery = `SELECT ihc.type, ihc.type, ` + reportHeadCountColumns + `
+	FROM (` + invitationHeadCountSubquery + `) ihc
+	GROUP BY ihc.type
+	ORDER BY ihc.type ASC
+`
+
+const reportTagHeadCountQuery = `SELECT t.id, t.name, ` + reportHeadCountColumns + `
+	FROM tags t
+	LEFT JOIN invitation_tags it
+	ON it.tag_id = t.id
+	LEFT JOIN (` + invitationHeadCountSubquery + `) ihc
+	ON ihc.invitation_id = it.invitation_id
+	GROUP BY t.id, t.name
+	ORDER BY t.name ASC
+`
+
+const reportStatusCountQuery = `SELECT u.status, COUNT(u.id)
+	FROM users u
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	WHERE i.status <> 'REVOKED'
+	GROUP BY u.status
+`
+
+const reportNonResponderQuery = `SELECT i.id, i.code, i.name, i.type, s.id, s.session_name,
+	COALESCE(u.id, ''), COALESCE(u.name, ''), COALESCE(u.status, ''), COALESCE(u.wa_number, i.wa_number)
+	FROM invitations i
+	JOIN invitation_sessions s
+	ON s.id = i.session_id
+	LEFT JOIN users u
+	ON u.invitation_id = i.id
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	LEFT JOIN user_rsvp_waitlists w
+	ON w.user_id = u.id
+	WHERE i.status <> 'REVOKED' AND ursvp.id IS NULL AND w.id IS NULL
+	ORDER BY s.start_time ASC NULLS LAST, s.session_name ASC, i.name ASC, u.name ASC
+`
+
+func (s *Report) FindRSVPReport(ctx context.Context) (*store.RSVPReportData, error) {
+	var err error
+	report := &store.RSVPReportData{}
+
+	if report.Sessions, err = s.findAllHeadCount(ctx, reportSessionHeadCountQuery); err != nil {
+		return nil, fmt.Errorf("failed to find session head count: %w", err)
+	}
+
+	if report.InvitationTypes, err = s.findAllHeadCount(ctx, reportInvitationTypeHeadCountQuery); err != nil {
+		return nil, fmt.Errorf("failed to find invitation type head count: %w", err)
+	}
+
+	if report.Tags, err = s.findAllHeadCount(ctx, reportTagHeadCountQuery); err != nil {
+		return nil, fmt.Errorf("failed to find tag head count: %w", err)
+	}
+
+	if report.Statuses, err = s.findAllStatusCount(ctx); err != nil {
+		return nil, fmt.Errorf("failed to find status count: %w", err)
+	}
+
+	if report.NonResponders, err = s.findAllNonResponder(ctx); err != nil {
+		return nil, fmt.Errorf("failed to find non responders: %w", err)
+	}
+
+	return report, nil
+}
+
+func (s *Report) findAllHeadCount(ctx context.Context, query string) ([]store.ReportHeadCountData, error) {
+	headCountList := []store.ReportHeadCountData{}
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		headCount := store.ReportHeadCountData{}
+		err := rows.Scan(
+			&headCount.ID, &headCount.Name,
+			&headCount.InvitationCount, &headCount.MaxSeats,
+			&headCount.UserCount, &headCount.RSVPUserCount,
+			&headCount.DeclinedUserCount, &headCount.MaybeUserCount,
+			&headCount.RSVPPeopleCount, &headCount.MaybePeopleCount,
+			&headCount.UnregisteredInvitationCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		headCountList = append(headCountList, headCount)
+	}
+
+	return headCountList, rows.Err()
+}
+
+func (s *Report) findAllStatusCount(ctx context.Context) ([]store.ReportStatusCountData, error) {
+	rows, err := s.db.QueryContext(ctx, reportStatusCountQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userCountByStatus := map[string]int64{}
+	otherStatuses := []string{}
+	for rows.Next() {
+		var status string
+		var userCount int64
+		if err := rows.Scan(&status, &userCount); err != nil {
+			return nil, err
+		}
+		userCountByStatus[status] = userCount
+		if !store.ContainsString(store.ReportUserStatuses, status) {
+			otherStatuses = append(otherStatuses, status)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statusCountList := []store.ReportStatusCountData{}
+	for _, status := range append(append([]string{}, store.ReportUserStatuses...), otherStatuses...) {
+		statusCountList = append(statusCountList, store.ReportStatusCountData{
+			Status:    status,
+			UserCount: userCountByStatus[status],
+		})
+	}
+
+	return statusCountList, nil
+}
+
+func (s *Report) findAllNonResponder(ctx context.Context) ([]store.ReportNonResponderData, error) {
+	nonResponderList := []store.ReportNonResponderData{}
+
+	rows, err := s.db.QueryContext(ctx, reportNonResponderQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		nonResponder := store.ReportNonResponderData{}
+		err := rows.Scan(
+			&nonResponder.InvitationID, &nonResponder.InvitationCode, &nonResponder.InvitationName, &nonResponder.InvitationType,
+			&nonResponder.SessionID, &nonResponder.SessionName,
+			&nonResponder.UserID, &nonResponder.UserName, &nonResponder.UserStatus, &nonResponder.WhatsAppNumber,
+		)
+		if err != nil {
+			return nil, err
+		}
+		nonResponderList = append(nonResponderList, nonResponder)
+	}
+
+	return nonResponderList, rows.Err()
+}
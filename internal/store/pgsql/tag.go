@@ -0,0 +1,244 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"be-wedding/internal/store"
+
+	"github.com/google/uuid"
+)
+
+type Tag struct {
+	db *sql.DB
+}
+
+func NewTag(db *sql.DB) *Tag {
+	return &Tag{db: db}
+}
+
+const tagInsertQuery = `INSERT INTO
+tags(
+	id, name, description, created_at
+) values(
+	$1, $2, $3, $4
+)
+ON CONFLICT DO NOTHING
+`
+
+func (s *Tag) Insert(ctx context.Context, tag *store.TagData) error {
+	tagID := uuid.NewString()
+	createdAt := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx, tagInsertQuery, tagID, tag.Name, tag.Description, createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return store.ErrTagNameTaken
+	}
+
+	tag.ID = tagID
+	tag.CreatedAt = createdAt
+
+	return nil
+}
+
+const tagNameTakenQuery = `SELECT EXISTS (
+	SELECT 1 FROM tags WHERE LOWER(name) = LOWER($1) AND id <> $2
+)
+`
+
+const tagUpdateQuery = `UPDATE tags
+	SET name = $2, description = $3, updated_at = $4
+	WHERE id = $1
+`
+
+func (s *Tag) Update(ctx context.Context, tag *store.TagData) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var nameTaken bool
+	if err = tx.QueryRowContext(ctx, tagNameTakenQuery, tag.Name, tag.ID).Scan(&nameTaken); err != nil {
+		return fmt.Errorf("failed to check tag name: %w", err)
+	}
+	if nameTaken {
+		return store.ErrTagNameTaken
+	}
+
+	updatedAt := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, tagUpdateQuery, tag.ID, tag.Name, tag.Description, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	tag.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+
+	return nil
+}
+
+const tagDeleteQuery = `DELETE FROM tags
+	WHERE id = $1
+`
+
+func (s *Tag) Delete(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, tagDeleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const tagFindAllQuery = `SELECT t.id, t.name, t.description, t.created_at, t.updated_at
+	FROM tags t
+	`
+
+func (s *Tag) findAll(ctx context.Context, query string, args ...interface{}) ([]*store.TagData, error) {
+	tagList := []*store.TagData{}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tag := &store.TagData{}
+		err := rows.Scan(
+			&tag.ID, &tag.Name, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tagList = append(tagList, tag)
+	}
+
+	return tagList, rows.Err()
+}
+
+func (s *Tag) FindAll(ctx context.Context) ([]*store.TagData, error) {
+	return s.findAll(ctx, tagFindAllQuery+`ORDER BY t.name ASC`)
+}
+
+func (s *Tag) FindOneByID(ctx context.Context, id string) (*store.TagData, error) {
+	tagList, err := s.findAll(ctx, tagFindAllQuery+`WHERE t.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tagList) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return tagList[0], nil
+}
+
+func (s *Tag) FindAllByInvitationID(ctx context.Context, invitationID string) ([]*store.TagData, error) {
+	return s.findAll(ctx, tagFindAllQuery+`JOIN invitation_tags it
+	ON it.tag_id = t.id
+	WHERE it.invitation_id = $1
+	ORDER BY t.name ASC`, invitationID)
+}
+
+const invitationTagInsertQuery = `INSERT INTO
+invitation_tags(
+	invitation_id, tag_id, created_at
+) values(
+	$1, $2, $3
+)
+ON CONFLICT DO NOTHING
+`
+
+func (s *Tag) AddToInvitation(ctx context.Context, invitationID string, tagIDs []string) error {
+	insertStmt, err := s.db.PrepareContext(ctx, invitationTagInsertQuery)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	createdAt := time.Now().UTC()
+	txInsertStmt := tx.StmtContext(ctx, insertStmt)
+	for _, tagID := range tagIDs {
+		if _, err = txInsertStmt.ExecContext(ctx, invitationID, tagID, createdAt); err != nil {
+			return fmt.Errorf("failed to insert: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+const invitationTagDeleteQuery = `DELETE FROM invitation_tags
+	WHERE invitation_id = $1 AND tag_id = $2
+`
+
+func (s *Tag) RemoveFromInvitation(ctx context.Context, invitationID string, tagID string) error {
+	result, err := s.db.ExecContext(ctx, invitationTagDeleteQuery, invitationID, tagID)
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+const tagFindAllHeadCountQuery = `SELECT t.id, t.name,
+	COUNT(ihc.invitation_id), COALESCE(SUM(ihc.max_seats), 0),
+	COALESCE(SUM(ihc.user_count), 0), COALESCE(SUM(ihc.rsvp_user_count), 0),
+	COALESCE(SUM(ihc.declined_user_count), 0), COALESCE(SUM(ihc.maybe_user_count), 0), COALESCE(SUM(ihc.rsvp_people_count), 0)
+	FROM tags t
+	LEFT JOIN invitation_tags it
+	ON it.tag_id = t.id
+	LEFT JOIN (` + invitationHeadCountSubquery + `) ihc
+	ON ihc.invitation_id = it.invitation_id
+	GROUP BY t.id, t.name
+	ORDER BY t.name ASC
+`
+
+func (s *Tag) FindAllHeadCount(ctx context.Context) ([]*store.TagHeadCountData, error) {
+	headCountList := []*store.TagHeadCountData{}
+
+	rows, err := s.db.QueryContext(ctx, tagFindAllHeadCountQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		headCount := &store.TagHeadCountData{}
+		err := rows.Scan(
+			&headCount.TagID, &headCount.TagName,
+			&headCount.InvitationCount, &headCount.MaxSeats,
+			&headCount.UserCount, &headCount.RSVPUserCount, &headCount.DeclinedUserCount, &headCount.MaybeUserCount,
+			&headCount.RSVPPeopleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		headCountList = append(headCountList, headCount)
+	}
+
+	return headCountList, rows.Err()
+}
@@ -3,6 +3,8 @@ package pgsql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -28,11 +30,6 @@ users(
 )
 `
 
-const invitationStatusUpdateQuery = `UPDATE invitations
-	SET status = $2, updated_at = $3
-	WHERE id = $1
-`
-
 func (s *User) Insert(ctx context.Context, user *store.UserData) error {
 	insertStmt, err := s.db.PrepareContext(ctx, userInsertQuery)
 	if err != nil {
@@ -44,7 +41,20 @@ func (s *User) Insert(ctx context.Context, user *store.UserData) error {
 	}
 	defer tx.Rollback()
 
+	var maxSeats int64
+	var status string
+	if err = tx.QueryRowContext(ctx, invitationLockStatusSeatQuery, user.InvitationID).Scan(&maxSeats, &status); err != nil {
+		return fmt.Errorf("failed to lock invitation: %w", err)
+	}
+	if err = invitationStatusError(status); err != nil {
+		return err
+	}
+
 	userID := uuid.NewString()
+	if err = reserveInvitationSeats(ctx, tx, user.InvitationID, maxSeats, userID, 1); err != nil {
+		return err
+	}
+
 	createdAt := time.Now().UTC()
 	_, err = tx.StmtContext(ctx, insertStmt).ExecContext(ctx,
 		userID, user.InvitationID, user.WhatsAppNumber, store.UserStatusNewlyCreated,
@@ -54,19 +64,6 @@ func (s *User) Insert(ctx context.Context, user *store.UserData) error {
 		return fmt.Errorf("failed to insert: %w", err)
 	}
 
-	// Update Invitation Status
-	if user.InvitationType == store.InvitationTypeSingle {
-
-		invitationUpdatedAt := time.Now().UTC()
-		updateInvitationStatusStmt, err := s.db.PrepareContext(ctx, invitationStatusUpdateQuery)
-		_, err = tx.StmtContext(ctx, updateInvitationStatusStmt).ExecContext(ctx,
-			user.InvitationID, store.InvitationStatusUsed, invitationUpdatedAt,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to update invitation status: %w", err)
-		}
-	}
-
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit: %w", err)
 	}
@@ -78,8 +75,9 @@ func (s *User) Insert(ctx context.Context, user *store.UserData) error {
 }
 
 const userUpdateQuery = `UPDATE users
-	SET name = $2, status = $3, updated_at = $4
+	SET name = $2, status = CASE WHEN status = $3 THEN $4 ELSE status END, updated_at = $5
 	WHERE id = $1
+	RETURNING status
 `
 
 func (s *User) Update(ctx context.Context, user *store.UserData) error {
@@ -94,9 +92,9 @@ func (s *User) Update(ctx context.Context, user *store.UserData) error {
 	defer tx.Rollback()
 
 	updatedAt := time.Now().UTC()
-	_, err = tx.StmtContext(ctx, updateStmt).ExecContext(ctx,
-		user.ID, user.Name, store.UserStatusInfoCompleted, updatedAt,
-	)
+	err = tx.StmtContext(ctx, updateStmt).QueryRowContext(ctx,
+		user.ID, user.Name, store.UserStatusNewlyCreated, store.UserStatusInfoCompleted, updatedAt,
+	).Scan(&user.Status)
 	if err != nil {
 		return fmt.Errorf("failed to update: %w", err)
 	}
@@ -105,13 +103,29 @@ func (s *User) Update(ctx context.Context, user *store.UserData) error {
 		return fmt.Errorf("failed to commit: %w", err)
 	}
 
-	user.Status = store.UserStatusInfoCompleted
 	user.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
 
 	return nil
 
 }
 
+const userUpdateQRImageQuery = `UPDATE users
+	SET qr_image = $2
+	WHERE id = $1
+`
+
+func (s *User) UpdateQRImage(ctx context.Context, userID string, qrImageName string) error {
+	result, err := s.db.ExecContext(ctx, userUpdateQRImageQuery, userID, qrImageName)
+	if err != nil {
+		return fmt.Errorf("failed to update qr image: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 const insertCommentQuery = `INSERT INTO
 user_comments(
 	id, user_id, comment, created_at
@@ -459,11 +473,28 @@ func (s *User) UpdateComment(ctx context.Context, userComment *store.UserComment
 
 }
 
-const insertUserRSVPQuery = `INSERT INTO
+const userRSVPInsertQuery = `INSERT INTO
 user_rsvps(
-	id, user_id, people_count, created_at
+	id, user_id, attendance, people_count, decline_message, created_at
 ) values(
-	$1, $2, $3, $4
+	$1, $2, $3, $4, NULLIF($5, ''), $6
+)
+`
+
+const userRSVPUpdateQuery = `UPDATE user_rsvps
+	SET attendance = $2, people_count = $3, decline_message = NULLIF($4, ''), updated_at = $5
+	WHERE id = $1
+`
+
+const userRSVPDeleteQuery = `DELETE FROM user_rsvps
+	WHERE id = $1
+`
+
+const userRSVPHistoryInsertQuery = `INSERT INTO
+user_rsvp_histories(
+	id, user_id, action, attendance, people_count, previous_people_count, decline_message, created_at
+) values(
+	$1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8
 )
 `
 
@@ -472,45 +503,944 @@ const userUpdateStatusQuery = `UPDATE users
 	WHERE id = $1
 `
 
-func (s *User) InsertUserRSVP(ctx context.Context, userRSVP *store.UserRSVPData) error {
-	insertStmt, err := s.db.PrepareContext(ctx, insertUserRSVPQuery)
+const userSessionLockQuery = `SELECT s.id, s.capacity, s.start_time, s.rsvp_opens_at, s.rsvp_closes_at
+	FROM invitation_sessions s
+	JOIN invitations i
+	ON i.session_id = s.id
+	JOIN users u
+	ON u.invitation_id = i.id
+	WHERE u.id = $1
+	FOR UPDATE OF s
+`
+
+const userInvitationLockQuery = `SELECT i.id, i.max_seats, i.rsvp_unlocked_until, ` + invitationStatusColumn + `
+	FROM invitations i
+	JOIN users u
+	ON u.invitation_id = i.id
+	WHERE u.id = $1
+	FOR UPDATE OF i
+`
+
+const userRSVPFindOneQuery = `SELECT u.status, COALESCE(ursvp.id, ''), COALESCE(ursvp.attendance, ''), COALESCE(ursvp.people_count, 0),
+	COALESCE(ursvp.created_at, u.created_at), ursvp.updated_at
+	FROM users u
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	WHERE u.id = $1
+	FOR UPDATE OF u
+`
+
+type userRSVPLock struct {
+	sessionID    string
+	capacity     sql.NullInt64
+	invitationID string
+	maxSeats     int64
+	current      *store.UserRSVPData
+}
+
+func (l *userRSVPLock) heldSeats() int64 {
+	if l.current.ID == "" {
+		return 1
+	}
+
+	return l.current.PeopleCount
+}
+
+// lockUserRSVP locks the session of the user, which serializes the RSVP changes competing for its seats,
+// then their invitation, then the user, and returns the current RSVP of the user. Locking a session before
+// its invitations in every RSVP change lets the waitlist promote the guests of other invitations safely,
+// locking the user serializes the change with their check-in. A non-nil check runs once the invitation is locked.
+func lockUserRSVP(ctx context.Context, tx *sql.Tx, userID string, check store.RSVPCheck) (*userRSVPLock, error) {
+	lock := &userRSVPLock{current: &store.UserRSVPData{UserID: userID}}
+	session := store.InvitationSessionData{}
+	err := tx.QueryRowContext(ctx, userSessionLockQuery, userID).Scan(
+		&lock.sessionID, &lock.capacity, &session.StartTime, &session.RSVPOpensAt, &session.RSVPClosesAt,
+	)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to lock session: %w", err)
 	}
-	tx, err := s.db.BeginTx(ctx, nil)
+	session.ID = lock.sessionID
+	session.Capacity = lock.capacity
+
+	invitation := store.InvitationData{}
+	err = tx.QueryRowContext(ctx, userInvitationLockQuery, userID).Scan(
+		&lock.invitationID, &lock.maxSeats, &invitation.RSVPUnlockedUntil, &invitation.Status,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to begin tx: %w", err)
+		return nil, fmt.Errorf("failed to lock invitation: %w", err)
+	}
+	invitation.ID = lock.invitationID
+	invitation.MaxSeats = lock.maxSeats
+	if err = invitationStatusError(invitation.Status); err != nil {
+		return nil, err
 	}
-	defer tx.Rollback()
 
-	userRSVPID := uuid.NewString()
-	createdAt := time.Now().UTC()
-	_, err = tx.StmtContext(ctx, insertStmt).ExecContext(ctx,
-		userRSVPID, userRSVP.UserID, userRSVP.PeopleCount, createdAt,
+	if check != nil {
+		if err = check(session, invitation); err != nil {
+			return nil, err
+		}
+	}
+
+	var status string
+	err = tx.QueryRowContext(ctx, userRSVPFindOneQuery, userID).Scan(
+		&status, &lock.current.ID, &lock.current.Attendance, &lock.current.PeopleCount, &lock.current.CreatedAt, &lock.current.UpdatedAt,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to insert: %w", err)
+		return nil, fmt.Errorf("failed to find rsvp: %w", err)
+	}
+	if status == store.UserStatusCheckedIn {
+		return nil, store.ErrAlreadyCheckedIn
 	}
 
-	updatedAt := time.Now().UTC()
-	updateStmt, err := s.db.PrepareContext(ctx, userUpdateStatusQuery)
+	return lock, nil
+}
+
+func insertUserRSVPHistory(ctx context.Context, tx *sql.Tx, history *store.UserRSVPHistoryData) error {
+	history.ID = uuid.NewString()
+	_, err := tx.ExecContext(ctx, userRSVPHistoryInsertQuery,
+		history.ID, history.UserID, history.Action, history.Attendance, history.PeopleCount, history.PreviousPeopleCount,
+		history.DeclineMessage, history.CreatedAt,
+	)
 	if err != nil {
+		return fmt.Errorf("failed to insert history: %w", err)
+	}
+
+	return nil
+}
+
+func applyUserRSVP(ctx context.Context, tx *sql.Tx, current *store.UserRSVPData, userRSVP *store.UserRSVPData, now time.Time) error {
+	var err error
+	history := &store.UserRSVPHistoryData{
+		UserID:         userRSVP.UserID,
+		Attendance:     userRSVP.Attendance,
+		DeclineMessage: userRSVP.DeclineMessage,
+		PeopleCount:    sql.NullInt64{Int64: userRSVP.PeopleCount, Valid: true},
+		CreatedAt:      now,
+	}
+	if current.ID == "" {
+		current.ID = uuid.NewString()
+		current.CreatedAt = now
+		_, err = tx.ExecContext(ctx, userRSVPInsertQuery,
+			current.ID, userRSVP.UserID, userRSVP.Attendance, userRSVP.PeopleCount, userRSVP.DeclineMessage, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert: %w", err)
+		}
+		history.Action = store.UserRSVPActionCreated
+	} else {
+		current.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		_, err = tx.ExecContext(ctx, userRSVPUpdateQuery,
+			current.ID, userRSVP.Attendance, userRSVP.PeopleCount, userRSVP.DeclineMessage, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update: %w", err)
+		}
+		history.Action = store.UserRSVPActionUpdated
+		history.PreviousPeopleCount = sql.NullInt64{Int64: current.PeopleCount, Valid: true}
+	}
+
+	if err = replaceAttendees(ctx, tx, userRSVPAttendeeDeleteQuery, userRSVPAttendeeInsertQuery, current.ID, userRSVP.Attendees, now); err != nil {
 		return err
 	}
-	_, err = tx.StmtContext(ctx, updateStmt).ExecContext(ctx,
-		userRSVP.UserID, store.UserStatusRSVPProvided, updatedAt,
-	)
+
+	if err = insertUserRSVPHistory(ctx, tx, history); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, userUpdateStatusQuery, userRSVP.UserID, userRSVP.UserStatus(), now)
 	if err != nil {
 		return fmt.Errorf("failed to update: %w", err)
 	}
 
+	userRSVP.ID = current.ID
+	userRSVP.CreatedAt = current.CreatedAt
+	userRSVP.UpdatedAt = current.UpdatedAt
+
+	return nil
+}
+
+func (s *User) SaveUserRSVP(ctx context.Context, userRSVP *store.UserRSVPData, check store.RSVPCheck) (*store.UserRSVPChangeData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	lock, err := lockUserRSVP(ctx, tx, userRSVP.UserID, check)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &store.UserRSVPChangeData{Promoted: []*store.UserRSVPWaitlistDetailData{}}
+
+	waitlisted, err := joinsUserRSVPWaitlist(ctx, tx, lock, userRSVP)
+	if err != nil {
+		return nil, err
+	}
+	if waitlisted {
+		if _, err = checkInvitationSeats(ctx, tx, lock.invitationID, lock.maxSeats, userRSVP.UserID, userRSVP.PeopleCount); err != nil {
+			return nil, err
+		}
+
+		if change.Waitlist, err = upsertUserRSVPWaitlist(ctx, tx, lock.sessionID, userRSVP); err != nil {
+			return nil, err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit: %w", err)
+		}
+
+		return change, nil
+	}
+
+	if err = reserveInvitationSeats(ctx, tx, lock.invitationID, lock.maxSeats, userRSVP.UserID, userRSVP.PeopleCount); err != nil {
+		return nil, err
+	}
+
+	if err = applyUserRSVP(ctx, tx, lock.current, userRSVP, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, userRSVPWaitlistDeleteByUserIDQuery, userRSVP.UserID); err != nil {
+		return nil, fmt.Errorf("failed to delete waitlist: %w", err)
+	}
+
+	if change.Promoted, err = promoteUserRSVPWaitlist(ctx, tx, lock.sessionID, lock.capacity); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit: %w", err)
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return change, nil
+}
+
+func (s *User) DeleteUserRSVP(ctx context.Context, userID string, check store.RSVPCheck) (*store.UserRSVPChangeData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	lock, err := lockUserRSVP(ctx, tx, userID, check)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, userRSVPWaitlistDeleteByUserIDQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete waitlist: %w", err)
+	}
+	waitlisted, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete waitlist: %w", err)
+	}
+	if lock.current.ID == "" && waitlisted == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	if lock.current.ID != "" {
+		if _, err = tx.ExecContext(ctx, userRSVPDeleteQuery, lock.current.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete: %w", err)
+		}
+
+		if err = releaseInvitationSeats(ctx, tx, lock.invitationID, lock.maxSeats, userID, 1); err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UTC()
+		err = insertUserRSVPHistory(ctx, tx, &store.UserRSVPHistoryData{
+			UserID:              userID,
+			Action:              store.UserRSVPActionCancelled,
+			PreviousPeopleCount: sql.NullInt64{Int64: lock.current.PeopleCount, Valid: true},
+			CreatedAt:           now,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, userUpdateStatusQuery, userID, store.UserStatusInfoCompleted, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update: %w", err)
+		}
+	}
+
+	change := &store.UserRSVPChangeData{}
+	if change.Promoted, err = promoteUserRSVPWaitlist(ctx, tx, lock.sessionID, lock.capacity); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return change, nil
+}
+
+const userRSVPWaitlistFromQuery = `FROM user_rsvp_waitlists w
+	JOIN users u
+	ON u.id = w.user_id
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	JOIN invitation_sessions s
+	ON s.id = i.session_id
+	WHERE i.status <> 'REVOKED'
+`
+
+const userRSVPWaitlistCountQuery = `SELECT COUNT(w.id) ` + userRSVPWaitlistFromQuery + `
+	AND s.id = $1 AND w.user_id <> $2
+`
+
+func joinsUserRSVPWaitlist(ctx context.Context, tx *sql.Tx, lock *userRSVPLock, userRSVP *store.UserRSVPData) (bool, error) {
+	if !lock.capacity.Valid || userRSVP.PeopleCount <= lock.heldSeats() {
+		return false, nil
+	}
+
+	var waitingCount int64
+	if err := tx.QueryRowContext(ctx, userRSVPWaitlistCountQuery, lock.sessionID, userRSVP.UserID).Scan(&waitingCount); err != nil {
+		return false, fmt.Errorf("failed to count waitlist: %w", err)
+	}
+	if waitingCount > 0 {
+		return true, nil
+	}
+
+	seats, err := findSessionSeats(ctx, tx, lock.sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	return seats-lock.heldSeats()+userRSVP.PeopleCount > lock.capacity.Int64, nil
+}
+
+const userRSVPWaitlistUpsertQuery = `INSERT INTO
+user_rsvp_waitlists(
+	id, user_id, attendance, people_count, created_at
+) values(
+	$1, $2, $3, $4, $5
+)
+ON CONFLICT (user_id) DO UPDATE
+	SET attendance = EXCLUDED.attendance, people_count = EXCLUDED.people_count, updated_at = EXCLUDED.created_at
+RETURNING id, created_at, updated_at
+`
+
+const userRSVPWaitlistPositionQuery = `SELECT COUNT(w.id) ` + userRSVPWaitlistFromQuery + `
+	AND s.id = $1 AND (w.created_at, w.id) <= ($2::TIMESTAMP WITH TIME ZONE, $3::TEXT)
+`
+
+const userRSVPWaitlistDeleteQuery = `DELETE FROM user_rsvp_waitlists
+	WHERE id = $1
+`
+
+const userRSVPWaitlistDeleteByUserIDQuery = `DELETE FROM user_rsvp_waitlists
+	WHERE user_id = $1
+`
+
+func upsertUserRSVPWaitlist(ctx context.Context, tx *sql.Tx, sessionID string, userRSVP *store.UserRSVPData) (*store.UserRSVPWaitlistData, error) {
+	now := time.Now().UTC()
+	waitlist := &store.UserRSVPWaitlistData{
+		UserID:      userRSVP.UserID,
+		Attendance:  userRSVP.Attendance,
+		PeopleCount: userRSVP.PeopleCount,
+		Attendees:   userRSVP.Attendees,
+	}
+	err := tx.QueryRowContext(ctx, userRSVPWaitlistUpsertQuery,
+		uuid.NewString(), userRSVP.UserID, userRSVP.Attendance, userRSVP.PeopleCount, now,
+	).Scan(&waitlist.ID, &waitlist.CreatedAt, &waitlist.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert waitlist: %w", err)
+	}
+
+	err = replaceAttendees(ctx, tx, userRSVPWaitlistAttendeeDeleteQuery, userRSVPWaitlistAttendeeInsertQuery, waitlist.ID, userRSVP.Attendees, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, userRSVPWaitlistPositionQuery, sessionID, waitlist.CreatedAt, waitlist.ID).Scan(&waitlist.Position)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find waitlist position: %w", err)
+	}
+
+	return waitlist, nil
+}
+
+const userRSVPWaitlistFindAllQuery = `SELECT w.id, w.user_id, w.attendance, w.people_count,
+	ROW_NUMBER() OVER (PARTITION BY s.id ORDER BY w.created_at ASC, w.id ASC), w.created_at, w.updated_at,
+	COALESCE(u.name, ''), u.wa_number, i.id, i.name, s.id, s.session_name,
+	COALESCE((SELECT ursvp.people_count FROM user_rsvps ursvp WHERE ursvp.user_id = u.id), 1)
+	` + userRSVPWaitlistFromQuery
+
+func findAllUserRSVPWaitlist(ctx context.Context, db querier, sessionID string) ([]*store.UserRSVPWaitlistDetailData, error) {
+	waitlistList := []*store.UserRSVPWaitlistDetailData{}
+
+	query := userRSVPWaitlistFindAllQuery
+	queryParams := []interface{}{}
+	if sessionID != "" {
+		queryParams = append(queryParams, sessionID)
+		query = query + fmt.Sprintf(`AND s.id = $%d `, len(queryParams))
+	}
+	query = query + `ORDER BY s.start_time ASC NULLS LAST, s.session_name ASC, w.created_at ASC, w.id ASC`
+
+	rows, err := db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	waitlistIDs := []string{}
+	for rows.Next() {
+		detail := &store.UserRSVPWaitlistDetailData{}
+		err := rows.Scan(
+			&detail.Waitlist.ID, &detail.Waitlist.UserID, &detail.Waitlist.Attendance, &detail.Waitlist.PeopleCount,
+			&detail.Waitlist.Position, &detail.Waitlist.CreatedAt, &detail.Waitlist.UpdatedAt,
+			&detail.UserName, &detail.WhatsAppNumber, &detail.InvitationID, &detail.InvitationName, &detail.SessionID, &detail.SessionName,
+			&detail.HeldSeats,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		waitlistList = append(waitlistList, detail)
+		waitlistIDs = append(waitlistIDs, detail.Waitlist.ID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(waitlistList) == 0 {
+		return waitlistList, nil
+	}
+
+	attendeesByWaitlistID, err := findAllUserRSVPWaitlistAttendee(ctx, db, waitlistIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find waitlist attendees: %w", err)
+	}
+	for _, detail := range waitlistList {
+		detail.Waitlist.Attendees = attendeesByWaitlistID[detail.Waitlist.ID]
+		if detail.Waitlist.Attendees == nil {
+			detail.Waitlist.Attendees = []store.UserRSVPAttendeeData{}
+		}
+	}
+
+	return waitlistList, nil
+}
+
+func promoteUserRSVPWaitlist(ctx context.Context, tx *sql.Tx, sessionID string, capacity sql.NullInt64) ([]*store.UserRSVPWaitlistDetailData, error) {
+	promoted := []*store.UserRSVPWaitlistDetailData{}
+
+	waitlist, err := findAllUserRSVPWaitlist(ctx, tx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find waitlist: %w", err)
+	}
+	if len(waitlist) == 0 {
+		return promoted, nil
+	}
+
+	seats, err := findSessionSeats(ctx, tx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, detail := range waitlist {
+		addedSeats := detail.Waitlist.PeopleCount - detail.HeldSeats
+		if capacity.Valid && addedSeats > 0 && seats+addedSeats > capacity.Int64 {
+			break
+		}
+
+		lock, err := lockUserRSVP(ctx, tx, detail.Waitlist.UserID, nil)
+		if errors.Is(err, store.ErrAlreadyCheckedIn) {
+			if _, err = tx.ExecContext(ctx, userRSVPWaitlistDeleteQuery, detail.Waitlist.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete waitlist: %w", err)
+			}
+			continue
+		}
+		if errors.Is(err, store.ErrInvitationExpired) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = reserveInvitationSeats(ctx, tx, lock.invitationID, lock.maxSeats, detail.Waitlist.UserID, detail.Waitlist.PeopleCount)
+		var seatQuotaErr *store.SeatQuotaError
+		if errors.As(err, &seatQuotaErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		userRSVP := &store.UserRSVPData{
+			UserID:      detail.Waitlist.UserID,
+			Attendance:  detail.Waitlist.Attendance,
+			PeopleCount: detail.Waitlist.PeopleCount,
+			Attendees:   detail.Waitlist.Attendees,
+		}
+		if err = applyUserRSVP(ctx, tx, lock.current, userRSVP, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+
+		if _, err = tx.ExecContext(ctx, userRSVPWaitlistDeleteQuery, detail.Waitlist.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete waitlist: %w", err)
+		}
+
+		seats += addedSeats
+		detail.RSVP = userRSVP
+		promoted = append(promoted, detail)
+	}
+
+	return promoted, nil
+}
+
+func (s *User) FindAllRSVPWaitlist(ctx context.Context, sessionID string) ([]*store.UserRSVPWaitlistDetailData, error) {
+	return findAllUserRSVPWaitlist(ctx, s.db, sessionID)
+}
+
+const invitationSessionLockQuery = `SELECT capacity
+	FROM invitation_sessions
+	WHERE id = $1
+	FOR UPDATE
+`
+
+func (s *User) PromoteRSVPWaitlist(ctx context.Context, sessionID string) ([]*store.UserRSVPWaitlistDetailData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, invitationSessionLockQuery, sessionID).Scan(&capacity); err != nil {
+		return nil, fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	promoted, err := promoteUserRSVPWaitlist(ctx, tx, sessionID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return promoted, nil
+}
+
+const userRSVPWaitlistSessionLockQuery = `SELECT s.id, s.capacity
+	FROM invitation_sessions s
+	JOIN invitations i
+	ON i.session_id = s.id
+	JOIN users u
+	ON u.invitation_id = i.id
+	JOIN user_rsvp_waitlists w
+	ON w.user_id = u.id
+	WHERE w.id = $1
+	FOR UPDATE OF s
+`
+
+func (s *User) DeleteRSVPWaitlist(ctx context.Context, id string) ([]*store.UserRSVPWaitlistDetailData, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var sessionID string
+	var capacity sql.NullInt64
+	if err = tx.QueryRowContext(ctx, userRSVPWaitlistSessionLockQuery, id).Scan(&sessionID, &capacity); err != nil {
+		return nil, fmt.Errorf("failed to lock session: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, userRSVPWaitlistDeleteQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to delete waitlist: %w", err)
+	}
+
+	promoted, err := promoteUserRSVPWaitlist(ctx, tx, sessionID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return promoted, nil
+}
+
+const userRSVPFindOneByUserIDQuery = `SELECT id, user_id, attendance, people_count, COALESCE(decline_message, ''), created_at, updated_at
+	FROM user_rsvps
+	WHERE user_id = $1
+`
+
+func (s *User) FindOneUserRSVPByUserID(ctx context.Context, userID string) (*store.UserRSVPData, error) {
+	userRSVP := &store.UserRSVPData{}
+
+	row := s.db.QueryRowContext(ctx, userRSVPFindOneByUserIDQuery, userID)
+
+	err := row.Scan(
+		&userRSVP.ID, &userRSVP.UserID, &userRSVP.Attendance, &userRSVP.PeopleCount, &userRSVP.DeclineMessage,
+		&userRSVP.CreatedAt, &userRSVP.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attendeesByUserID, err := findAllUserRSVPAttendee(ctx, s.db, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	userRSVP.Attendees = attendeesByUserID[userID]
+
+	return userRSVP, nil
+}
+
+const userRSVPHistoryFindAllByUserIDQuery = `SELECT id, user_id, action, COALESCE(attendance, ''), people_count, previous_people_count,
+	COALESCE(decline_message, ''), created_at
+	FROM user_rsvp_histories
+	WHERE user_id = $1
+	ORDER BY created_at ASC, id ASC
+`
+
+func (s *User) FindAllUserRSVPHistoryByUserID(ctx context.Context, userID string) ([]*store.UserRSVPHistoryData, error) {
+	historyList := []*store.UserRSVPHistoryData{}
+
+	rows, err := s.db.QueryContext(ctx, userRSVPHistoryFindAllByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		history := &store.UserRSVPHistoryData{}
+		err := rows.Scan(
+			&history.ID, &history.UserID, &history.Action, &history.Attendance, &history.PeopleCount, &history.PreviousPeopleCount,
+			&history.DeclineMessage, &history.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		historyList = append(historyList, history)
+	}
+
+	return historyList, rows.Err()
+}
+
+const userFindAllCheckInDataQuery = `SELECT u.id, u.invitation_id, i.type, u.wa_number, COALESCE(u.name, ''), u.status,
+	COALESCE(u.qr_image, ''), u.created_at, u.updated_at,
+	i.id, i.code, i.session_id, i.type, i.name, ` + invitationStatusColumn + `, i.wa_number, i.max_seats, i.expires_at,
+	COALESCE(i.table_name, ''), i.rsvp_unlocked_until,
+	s.id, s.session_name, s.schedule, s.start_time, s.end_time, s.venue, s.capacity, s.rsvp_opens_at, s.rsvp_closes_at,
+	COALESCE(ursvp.attendance, ''), ursvp.people_count,
+	(SELECT COALESCE(SUM(ci.people_count), 0) FROM check_ins ci WHERE ci.user_id = u.id)
+	FROM users u
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	JOIN invitation_sessions s
+	ON s.id = i.session_id
+	LEFT JOIN user_rsvps ursvp
+	ON ursvp.user_id = u.id
+	`
+
+func (s *User) findAllCheckInData(ctx context.Context, query string, args ...interface{}) ([]*store.UserCheckInData, error) {
+	checkInList := []*store.UserCheckInData{}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		checkIn := &store.UserCheckInData{}
+		err := rows.Scan(
+			&checkIn.User.ID, &checkIn.User.InvitationID, &checkIn.User.InvitationType, &checkIn.User.WhatsAppNumber,
+			&checkIn.User.Name, &checkIn.User.Status, &checkIn.User.QRImageName, &checkIn.User.CreatedAt, &checkIn.User.UpdatedAt,
+			&checkIn.Invitation.ID, &checkIn.Invitation.Code, &checkIn.Invitation.SessionID, &checkIn.Invitation.Type,
+			&checkIn.Invitation.Name, &checkIn.Invitation.Status, &checkIn.Invitation.WhatsAppNumber, &checkIn.Invitation.MaxSeats,
+			&checkIn.Invitation.ExpiresAt, &checkIn.Invitation.TableName, &checkIn.Invitation.RSVPUnlockedUntil,
+			&checkIn.Session.ID, &checkIn.Session.Name, &checkIn.Session.Schedule, &checkIn.Session.StartTime, &checkIn.Session.EndTime,
+			&checkIn.Session.Venue, &checkIn.Session.Capacity, &checkIn.Session.RSVPOpensAt, &checkIn.Session.RSVPClosesAt,
+			&checkIn.RSVPAttendance, &checkIn.RSVPPeopleCount, &checkIn.CheckedInPeople,
+		)
+		if err != nil {
+			return nil, err
+		}
+		checkInList = append(checkInList, checkIn)
 	}
 
-	userRSVP.ID = userRSVPID
-	userRSVP.CreatedAt = createdAt
+	return checkInList, rows.Err()
+}
+
+func (s *User) FindOneCheckInDataByID(ctx context.Context, id string) (*store.UserCheckInData, error) {
+	checkInList, err := s.findAllCheckInData(ctx, userFindAllCheckInDataQuery+`WHERE u.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkInList) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return checkInList[0], nil
+}
+
+func (s *User) FindAllCheckInData(ctx context.Context, filter store.UserCheckInFilter) ([]*store.UserCheckInData, error) {
+	query := userFindAllCheckInDataQuery + `WHERE i.status <> 'REVOKED' `
+	queryParams := []interface{}{}
+
+	if filter.InvitationID != "" {
+		queryParams = append(queryParams, filter.InvitationID)
+		query = query + fmt.Sprintf(`AND i.id = $%d `, len(queryParams))
+	}
+	if filter.SessionID != "" {
+		queryParams = append(queryParams, filter.SessionID)
+		query = query + fmt.Sprintf(`AND i.session_id = $%d `, len(queryParams))
+	}
+	if filter.TagID != "" {
+		queryParams = append(queryParams, filter.TagID)
+		query = query + fmt.Sprintf(`AND EXISTS (SELECT 1 FROM invitation_tags it WHERE it.invitation_id = i.id AND it.tag_id = $%d) `, len(queryParams))
+	}
+
+	return s.findAllCheckInData(ctx, query+`ORDER BY i.table_name ASC NULLS LAST, u.name ASC, u.created_at ASC`, queryParams...)
+}
+
+const userRSVPAttendeeDeleteQuery = `DELETE FROM user_rsvp_attendees
+	WHERE user_rsvp_id = $1
+`
+
+const userRSVPAttendeeInsertQuery = `INSERT INTO
+user_rsvp_attendees(
+	id, user_rsvp_id, position, name, age_group, dietary_restrictions, wheelchair, notes, created_at
+) values(
+	$1, $2, $3, $4, $5, COALESCE($6::TEXT[], '{}'), $7, $8, $9
+)
+`
+
+const userRSVPWaitlistAttendeeDeleteQuery = `DELETE FROM user_rsvp_waitlist_attendees
+	WHERE user_rsvp_waitlist_id = $1
+`
+
+const userRSVPWaitlistAttendeeInsertQuery = `INSERT INTO
+user_rsvp_waitlist_attendees(
+	id, user_rsvp_waitlist_id, position, name, age_group, dietary_restrictions, wheelchair, notes, created_at
+) values(
+	$1, $2, $3, $4, $5, COALESCE($6::TEXT[], '{}'), $7, $8, $9
+)
+`
+
+func replaceAttendees(ctx context.Context, tx *sql.Tx, deleteQuery string, insertQuery string, parentID string, attendees []store.UserRSVPAttendeeData, createdAt time.Time) error {
+	if _, err := tx.ExecContext(ctx, deleteQuery, parentID); err != nil {
+		return fmt.Errorf("failed to delete attendees: %w", err)
+	}
+
+	for idx, attendee := range attendees {
+		_, err := tx.ExecContext(ctx, insertQuery,
+			uuid.NewString(), parentID, idx, attendee.Name, attendee.AgeGroup,
+			attendee.DietaryRestrictions, attendee.Wheelchair, attendee.Notes, createdAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert attendee: %w", err)
+		}
+	}
 
 	return nil
+}
+
+// userRSVPAttendeeColumns reads the dietary restrictions array as JSON, database/sql cannot scan an array.
+const userRSVPAttendeeColumns = `ura.name, ura.age_group, array_to_json(ura.dietary_restrictions), ura.wheelchair, ura.notes`
+
+func scanUserRSVPAttendee(row interface{ Scan(...interface{}) error }, attendee *store.UserRSVPAttendeeData, dest ...interface{}) error {
+	var dietaryRestrictions []byte
+	dest = append([]interface{}{
+		&attendee.Name, &attendee.AgeGroup, &dietaryRestrictions, &attendee.Wheelchair, &attendee.Notes,
+	}, dest...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(dietaryRestrictions, &attendee.DietaryRestrictions); err != nil {
+		return fmt.Errorf("failed to decode dietary restrictions: %w", err)
+	}
+	if attendee.DietaryRestrictions == nil {
+		attendee.DietaryRestrictions = []string{}
+	}
+
+	return nil
+}
+
+const userRSVPAttendeeFindAllByUserIDQuery = `SELECT ` + userRSVPAttendeeColumns + `, ursvp.user_id
+	FROM user_rsvp_attendees ura
+	JOIN user_rsvps ursvp
+	ON ursvp.id = ura.user_rsvp_id
+	WHERE ursvp.user_id = ANY($1)
+	ORDER BY ura.position ASC
+`
+
+func findAllUserRSVPAttendee(ctx context.Context, db querier, userIDs []string) (map[string][]store.UserRSVPAttendeeData, error) {
+	attendeesByUserID := map[string][]store.UserRSVPAttendeeData{}
+
+	rows, err := db.QueryContext(ctx, userRSVPAttendeeFindAllByUserIDQuery, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		attendee := store.UserRSVPAttendeeData{}
+		if err := scanUserRSVPAttendee(rows, &attendee, &userID); err != nil {
+			return nil, err
+		}
+		attendeesByUserID[userID] = append(attendeesByUserID[userID], attendee)
+	}
+
+	return attendeesByUserID, rows.Err()
+}
+
+const userRSVPWaitlistAttendeeFindAllQuery = `SELECT ` + userRSVPAttendeeColumns + `, ura.user_rsvp_waitlist_id
+	FROM user_rsvp_waitlist_attendees ura
+	WHERE ura.user_rsvp_waitlist_id = ANY($1)
+	ORDER BY ura.position ASC
+`
+
+func findAllUserRSVPWaitlistAttendee(ctx context.Context, db querier, waitlistIDs []string) (map[string][]store.UserRSVPAttendeeData, error) {
+	attendeesByWaitlistID := map[string][]store.UserRSVPAttendeeData{}
+
+	rows, err := db.QueryContext(ctx, userRSVPWaitlistAttendeeFindAllQuery, waitlistIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var waitlistID string
+		attendee := store.UserRSVPAttendeeData{}
+		if err := scanUserRSVPAttendee(rows, &attendee, &waitlistID); err != nil {
+			return nil, err
+		}
+		attendeesByWaitlistID[waitlistID] = append(attendeesByWaitlistID[waitlistID], attendee)
+	}
+
+	return attendeesByWaitlistID, rows.Err()
+}
+
+func userRSVPAttendeeFilterQuery(query string, filter store.UserRSVPAttendeeFilter) (string, []interface{}) {
+	queryParams := []interface{}{}
+
+	if filter.SessionID != "" {
+		queryParams = append(queryParams, filter.SessionID)
+		query = query + fmt.Sprintf(`AND i.session_id = $%d `, len(queryParams))
+	}
+	if filter.TagID != "" {
+		queryParams = append(queryParams, filter.TagID)
+		query = query + fmt.Sprintf(`AND EXISTS (SELECT 1 FROM invitation_tags it WHERE it.invitation_id = i.id AND it.tag_id = $%d) `, len(queryParams))
+	}
+
+	return query, queryParams
+}
+
+const userRSVPAttendeeFindAllQuery = `SELECT ` + userRSVPAttendeeColumns + `,
+	u.id, COALESCE(u.name, ''), ursvp.attendance, i.id, i.name, COALESCE(i.table_name, ''), s.id, s.session_name
+	FROM user_rsvp_attendees ura
+	JOIN user_rsvps ursvp
+	ON ursvp.id = ura.user_rsvp_id
+	JOIN users u
+	ON u.id = ursvp.user_id
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	JOIN invitation_sessions s
+	ON s.id = i.session_id
+	WHERE i.status <> 'REVOKED'
+	`
+
+func (s *User) FindAllRSVPAttendee(ctx context.Context, filter store.UserRSVPAttendeeFilter) ([]*store.UserRSVPAttendeeDetailData, error) {
+	attendeeList := []*store.UserRSVPAttendeeDetailData{}
+
+	query, queryParams := userRSVPAttendeeFilterQuery(userRSVPAttendeeFindAllQuery, filter)
+	query = query + `ORDER BY i.table_name ASC NULLS LAST, u.name ASC, u.id ASC, ura.position ASC`
+
+	rows, err := s.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		detail := &store.UserRSVPAttendeeDetailData{}
+		err := scanUserRSVPAttendee(rows, &detail.Attendee,
+			&detail.UserID, &detail.UserName, &detail.Attendance, &detail.InvitationID, &detail.InvitationName,
+			&detail.TableName, &detail.SessionID, &detail.SessionName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attendeeList = append(attendeeList, detail)
+	}
+
+	return attendeeList, rows.Err()
+}
+
+const userRSVPAttendeeCountQuery = `SELECT
+	COUNT(ura.id),
+	COUNT(ura.id) FILTER (WHERE ura.age_group = 'ADULT'),
+	COUNT(ura.id) FILTER (WHERE ura.age_group = 'CHILD'),
+	COUNT(ura.id) FILTER (WHERE ura.wheelchair),
+	COUNT(ura.id) FILTER (WHERE ursvp.attendance = 'MAYBE'),
+	COALESCE(SUM(ursvp.people_count) FILTER (WHERE ura.id IS NULL), 0)
+	FROM user_rsvps ursvp
+	JOIN users u
+	ON u.id = ursvp.user_id
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	LEFT JOIN user_rsvp_attendees ura
+	ON ura.user_rsvp_id = ursvp.id
+	WHERE i.status <> 'REVOKED' AND ursvp.attendance <> 'NO'
+	`
+
+const userRSVPAttendeeDietaryCountQuery = `SELECT dr.restriction, COUNT(*)
+	FROM user_rsvp_attendees ura
+	CROSS JOIN LATERAL unnest(ura.dietary_restrictions) AS dr(restriction)
+	JOIN user_rsvps ursvp
+	ON ursvp.id = ura.user_rsvp_id
+	JOIN users u
+	ON u.id = ursvp.user_id
+	JOIN invitations i
+	ON i.id = u.invitation_id
+	WHERE i.status <> 'REVOKED' AND ursvp.attendance <> 'NO'
+	`
+
+func (s *User) FindRSVPAttendeeSummary(ctx context.Context, filter store.UserRSVPAttendeeFilter) (*store.UserRSVPAttendeeSummaryData, error) {
+	summary := &store.UserRSVPAttendeeSummaryData{
+		DietaryRestrictions: map[string]int64{},
+	}
+	for _, restriction := range store.DietaryRestrictions {
+		summary.DietaryRestrictions[restriction] = 0
+	}
+
+	query, queryParams := userRSVPAttendeeFilterQuery(userRSVPAttendeeCountQuery, filter)
+	err := s.db.QueryRowContext(ctx, query, queryParams...).Scan(
+		&summary.AttendeeCount, &summary.AdultCount, &summary.ChildCount, &summary.WheelchairCount,
+		&summary.TentativeCount, &summary.UnlistedPeople,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attendees: %w", err)
+	}
+
+	query, queryParams = userRSVPAttendeeFilterQuery(userRSVPAttendeeDietaryCountQuery, filter)
+	rows, err := s.db.QueryContext(ctx, query+`GROUP BY dr.restriction`, queryParams...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dietary restrictions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var restriction string
+		var count int64
+		if err := rows.Scan(&restriction, &count); err != nil {
+			return nil, err
+		}
+		summary.DietaryRestrictions[restriction] = count
+	}
 
+	return summary, rows.Err()
 }
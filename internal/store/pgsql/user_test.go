@@ -0,0 +1,28 @@
+package pgsql
+
+import (
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func TestUserRSVPLockHeldSeats(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *store.UserRSVPData
+		want    int64
+	}{
+		{name: "no rsvp", current: &store.UserRSVPData{}, want: 1},
+		{name: "declined", current: &store.UserRSVPData{ID: "r1", PeopleCount: 0}, want: 0},
+		{name: "group", current: &store.UserRSVPData{ID: "r1", PeopleCount: 4}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lock := &userRSVPLock{current: tt.current}
+			if got := lock.heldSeats(); got != tt.want {
+				t.Errorf("heldSeats() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPlusOneNotAllowed = errors.New("plus-one requests are only open to guests of a single invitation")
+	ErrPlusOnePending    = errors.New("user already has a pending plus-one request")
+	ErrPlusOneDecided    = errors.New("plus-one request has already been decided")
+	ErrPlusOneNoSeat     = errors.New("session has no seat left for a plus-one")
+)
+
+const (
+	PlusOneStatusPending  = "PENDING"
+	PlusOneStatusApproved = "APPROVED"
+	PlusOneStatusRejected = "REJECTED"
+)
+
+type PlusOneRequestData struct {
+	ID           string
+	InvitationID string
+	UserID       string
+	Name         string
+	Status       string
+	DecidedBy    string
+	DecisionNote string
+
+	CreatedAt time.Time
+	DecidedAt sql.NullTime
+}
+
+type PlusOneRequestDetailData struct {
+	Request        PlusOneRequestData
+	UserName       string
+	WhatsAppNumber string
+	InvitationName string
+	InvitationType string
+	MaxSeats       int64
+}
+
+type PlusOneRequestFilter struct {
+	UserID string
+	Status string
+}
+
+type PlusOne interface {
+	Insert(ctx context.Context, request *PlusOneRequestData) error
+	FindAll(ctx context.Context, filter PlusOneRequestFilter) ([]*PlusOneRequestDetailData, error)
+	Decide(ctx context.Context, request *PlusOneRequestData) (*PlusOneRequestDetailData, []*UserRSVPWaitlistDetailData, error)
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRedemptionItemNameTaken = errors.New("redemption item name is already used")
+	ErrRedemptionItemInUse     = errors.New("redemption item has already been redeemed")
+)
+
+const (
+	RedemptionAllowancePerPerson     = "PER_PERSON"
+	RedemptionAllowancePerInvitation = "PER_INVITATION"
+)
+
+type RedemptionItemData struct {
+	ID            string
+	Name          string
+	Description   string
+	AllowanceType string
+	Allowance     int64
+	Stock         sql.NullInt64
+
+	CreatedAt time.Time
+	UpdatedAt sql.NullTime
+}
+
+type RedemptionData struct {
+	ID           string
+	ItemID       string
+	InvitationID string
+	UserID       string
+	Quantity     int64
+	RedeemedBy   string
+
+	CreatedAt time.Time
+}
+
+type RedemptionAllowanceData struct {
+	Item          RedemptionItemData
+	Allowance     int64
+	Redeemed      int64
+	TotalRedeemed int64
+}
+
+func (d RedemptionAllowanceData) Left() int64 {
+	if d.Redeemed >= d.Allowance {
+		return 0
+	}
+
+	return d.Allowance - d.Redeemed
+}
+
+func (d RedemptionAllowanceData) StockLeft() (int64, bool) {
+	if !d.Item.Stock.Valid {
+		return 0, false
+	}
+
+	return d.Item.Stock.Int64 - d.TotalRedeemed, true
+}
+
+type RedemptionStockData struct {
+	Item            RedemptionItemData
+	InvitationCount int64
+	TotalAllowance  int64
+	TotalRedeemed   int64
+}
+
+type RedemptionQuotaError struct {
+	Allowance int64
+	Redeemed  int64
+	Left      int64
+}
+
+func (e *RedemptionQuotaError) Error() string {
+	return "redemption exceeds the allowance of this invitation"
+}
+
+type RedemptionStockError struct {
+	Stock    int64
+	Redeemed int64
+}
+
+func (e *RedemptionStockError) StockLeft() int64 {
+	if e.Redeemed >= e.Stock {
+		return 0
+	}
+
+	return e.Stock - e.Redeemed
+}
+
+func (e *RedemptionStockError) Error() string {
+	return "redemption exceeds the stock left"
+}
+
+type Redemption interface {
+	InsertItem(ctx context.Context, item *RedemptionItemData) error
+	UpdateItem(ctx context.Context, item *RedemptionItemData) error
+	DeleteItem(ctx context.Context, id string) error
+	FindAllItem(ctx context.Context) ([]*RedemptionItemData, error)
+	FindOneItemByID(ctx context.Context, id string) (*RedemptionItemData, error)
+	Insert(ctx context.Context, redemption *RedemptionData) error
+	FindAllAllowanceByInvitationID(ctx context.Context, invitationID string) ([]*RedemptionAllowanceData, error)
+	FindAllStock(ctx context.Context) ([]*RedemptionStockData, error)
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestRedemptionAllowanceData(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowance     RedemptionAllowanceData
+		wantLeft      int64
+		wantStockLeft int64
+		wantStockOK   bool
+	}{
+		{name: "nothing redeemed", allowance: RedemptionAllowanceData{Allowance: 3}, wantLeft: 3},
+		{name: "over redeemed", allowance: RedemptionAllowanceData{Allowance: 2, Redeemed: 3}, wantLeft: 0},
+		{
+			name:          "counted stock",
+			allowance:     RedemptionAllowanceData{Item: RedemptionItemData{Stock: sql.NullInt64{Int64: 10, Valid: true}}, Allowance: 2, Redeemed: 1, TotalRedeemed: 7},
+			wantLeft:      1,
+			wantStockLeft: 3,
+			wantStockOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.allowance.Left(); got != tt.wantLeft {
+				t.Errorf("Left() = %d, want %d", got, tt.wantLeft)
+			}
+			stockLeft, ok := tt.allowance.StockLeft()
+			if stockLeft != tt.wantStockLeft || ok != tt.wantStockOK {
+				t.Errorf("StockLeft() = %d, %v, want %d, %v", stockLeft, ok, tt.wantStockLeft, tt.wantStockOK)
+			}
+		})
+	}
+}
+
+func TestRedemptionStockErrorStockLeft(t *testing.T) {
+	if got := (&RedemptionStockError{Stock: 10, Redeemed: 4}).StockLeft(); got != 6 {
+		t.Errorf("StockLeft() = %d, want 6", got)
+	}
+	if got := (&RedemptionStockError{Stock: 5, Redeemed: 8}).StockLeft(); got != 0 {
+		t.Errorf("StockLeft() over redeemed = %d, want 0", got)
+	}
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+)
+
+var ReportUserStatuses = []string{
+	UserStatusNewlyCreated,
+	UserStatusInfoCompleted,
+	UserStatusRSVPProvided,
+	UserStatusRSVPTentative,
+	UserStatusRSVPDeclined,
+	UserStatusCheckedIn,
+}
+
+type ReportHeadCountData struct {
+	ID                          string
+	Name                        string
+	InvitationCount             int64
+	MaxSeats                    int64
+	UserCount                   int64
+	RSVPUserCount               int64
+	DeclinedUserCount           int64
+	MaybeUserCount              int64
+	RSVPPeopleCount             int64
+	MaybePeopleCount            int64
+	UnregisteredInvitationCount int64
+}
+
+type ReportStatusCountData struct {
+	Status    string
+	UserCount int64
+}
+
+type ReportNonResponderData struct {
+	InvitationID   string
+	InvitationCode string
+	InvitationName string
+	InvitationType string
+	SessionID      string
+	SessionName    string
+	UserID         string
+	UserName       string
+	UserStatus     string
+	WhatsAppNumber string
+}
+
+type RSVPReportData struct {
+	Sessions        []ReportHeadCountData
+	InvitationTypes []ReportHeadCountData
+	Tags            []ReportHeadCountData
+	Statuses        []ReportStatusCountData
+	NonResponders   []ReportNonResponderData
+}
+
+type Report interface {
+	FindRSVPReport(ctx context.Context) (*RSVPReportData, error)
+}
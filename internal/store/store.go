@@ -0,0 +1,11 @@
+package store
+
+func ContainsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrTagNameTaken = errors.New("tag name is already used")
+
+type TagData struct {
+	ID          string
+	Name        string
+	Description string
+
+	CreatedAt time.Time
+	UpdatedAt sql.NullTime
+}
+
+type TagHeadCountData struct {
+	TagID             string
+	TagName           string
+	InvitationCount   int64
+	MaxSeats          int64
+	UserCount         int64
+	RSVPUserCount     int64
+	DeclinedUserCount int64
+	MaybeUserCount    int64
+	RSVPPeopleCount   int64
+}
+
+type Tag interface {
+	Insert(ctx context.Context, tag *TagData) error
+	Update(ctx context.Context, tag *TagData) error
+	Delete(ctx context.Context, id string) error
+	FindAll(ctx context.Context) ([]*TagData, error)
+	FindOneByID(ctx context.Context, id string) (*TagData, error)
+	FindAllByInvitationID(ctx context.Context, invitationID string) ([]*TagData, error)
+	AddToInvitation(ctx context.Context, invitationID string, tagIDs []string) error
+	RemoveFromInvitation(ctx context.Context, invitationID string, tagID string) error
+	FindAllHeadCount(ctx context.Context) ([]*TagHeadCountData, error)
+}
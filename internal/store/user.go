@@ -10,8 +10,34 @@ const (
 	UserStatusNewlyCreated  = "NEWLY_CREATED"
 	UserStatusInfoCompleted = "INFO_COMPLETED"
 	UserStatusRSVPProvided  = "RSVP_PROVIDED"
+	UserStatusRSVPTentative = "RSVP_TENTATIVE"
+	UserStatusRSVPDeclined  = "RSVP_DECLINED"
+	UserStatusCheckedIn     = "CHECKED_IN"
 )
 
+const (
+	UserRSVPActionCreated   = "CREATED"
+	UserRSVPActionUpdated   = "UPDATED"
+	UserRSVPActionCancelled = "CANCELLED"
+)
+
+const (
+	UserRSVPAttendanceYes   = "YES"
+	UserRSVPAttendanceNo    = "NO"
+	UserRSVPAttendanceMaybe = "MAYBE"
+)
+
+const (
+	AttendeeAgeGroupAdult = "ADULT"
+	AttendeeAgeGroupChild = "CHILD"
+
+	AttendeeNameMaxLength = 100
+)
+
+var DietaryRestrictions = []string{
+	"VEGETARIAN", "VEGAN", "HALAL", "NO_PORK", "NO_BEEF", "NO_SEAFOOD", "NUT_ALLERGY", "GLUTEN_FREE", "DAIRY_FREE",
+}
+
 type UserData struct {
 	ID             string
 	InvitationID   string
@@ -49,15 +75,134 @@ type UserCommentLikeCountData struct {
 }
 
 type UserRSVPData struct {
+	ID             string
+	UserID         string
+	Attendance     string
+	PeopleCount    int64
+	DeclineMessage string
+	Attendees      []UserRSVPAttendeeData
+	CreatedAt      time.Time
+	UpdatedAt      sql.NullTime
+}
+
+type UserRSVPAttendeeData struct {
+	Name                string
+	AgeGroup            string
+	DietaryRestrictions []string
+	Wheelchair          bool
+	Notes               string
+}
+
+type UserRSVPAttendeeDetailData struct {
+	Attendee       UserRSVPAttendeeData
+	UserID         string
+	UserName       string
+	Attendance     string
+	InvitationID   string
+	InvitationName string
+	TableName      string
+	SessionID      string
+	SessionName    string
+}
+
+type UserRSVPAttendeeSummaryData struct {
+	AttendeeCount       int64
+	AdultCount          int64
+	ChildCount          int64
+	WheelchairCount     int64
+	TentativeCount      int64
+	UnlistedPeople      int64
+	DietaryRestrictions map[string]int64
+}
+
+type UserRSVPWaitlistData struct {
 	ID          string
 	UserID      string
+	Attendance  string
 	PeopleCount int64
+	Attendees   []UserRSVPAttendeeData
+	Position    int64
 	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
 }
 
+type UserRSVPWaitlistDetailData struct {
+	Waitlist       UserRSVPWaitlistData
+	UserName       string
+	WhatsAppNumber string
+	InvitationID   string
+	InvitationName string
+	SessionID      string
+	SessionName    string
+	HeldSeats      int64
+	RSVP           *UserRSVPData
+}
+
+type UserRSVPChangeData struct {
+	Waitlist *UserRSVPWaitlistData
+	Promoted []*UserRSVPWaitlistDetailData
+}
+
+type UserRSVPAttendeeFilter struct {
+	SessionID string
+	TagID     string
+}
+
+func (d *UserRSVPData) UserStatus() string {
+	switch d.Attendance {
+	case UserRSVPAttendanceNo:
+		return UserStatusRSVPDeclined
+	case UserRSVPAttendanceMaybe:
+		return UserStatusRSVPTentative
+	default:
+		return UserStatusRSVPProvided
+	}
+}
+
+type UserRSVPHistoryData struct {
+	ID                  string
+	UserID              string
+	Action              string
+	Attendance          string
+	DeclineMessage      string
+	PeopleCount         sql.NullInt64
+	PreviousPeopleCount sql.NullInt64
+	CreatedAt           time.Time
+}
+
+type UserCheckInData struct {
+	User            UserData
+	Invitation      InvitationData
+	Session         InvitationSessionData
+	RSVPAttendance  string
+	RSVPPeopleCount sql.NullInt64
+	CheckedInPeople int64
+}
+
+func (d *UserCheckInData) AllowedPeople() int64 {
+	if d.RSVPPeopleCount.Valid && d.RSVPPeopleCount.Int64 > 0 {
+		return d.RSVPPeopleCount.Int64
+	}
+
+	return 1
+}
+
+func (d *UserCheckInData) Declined() bool {
+	return d.RSVPAttendance == UserRSVPAttendanceNo
+}
+
+type UserCheckInFilter struct {
+	InvitationID string
+	SessionID    string
+	TagID        string
+}
+
+type RSVPCheck func(session InvitationSessionData, invitation InvitationData) error
+
 type User interface {
 	Insert(ctx context.Context, user *UserData) error
 	Update(ctx context.Context, user *UserData) error
+	UpdateQRImage(ctx context.Context, userID string, qrImageName string) error
 	InsertComment(ctx context.Context, userComment *UserCommentData) error
 	UpdateComment(ctx context.Context, userComment *UserCommentData) error
 	FindAllComment(ctx context.Context, startDate string, endDate string) ([]*UserCommentData, error)
@@ -66,5 +211,15 @@ type User interface {
 	FindLikedCommentOnlyByUserID(ctx context.Context, userID string) ([]*UserCommentLikeData, error)
 	FindLikedCommentCount(ctx context.Context) ([]*UserCommentLikeCountData, error)
 	FindOneCommentByUserID(ctx context.Context, userID string) (*UserCommentData, error)
-	InsertUserRSVP(ctx context.Context, userRSVP *UserRSVPData) error
+	SaveUserRSVP(ctx context.Context, userRSVP *UserRSVPData, check RSVPCheck) (*UserRSVPChangeData, error)
+	DeleteUserRSVP(ctx context.Context, userID string, check RSVPCheck) (*UserRSVPChangeData, error)
+	FindOneUserRSVPByUserID(ctx context.Context, userID string) (*UserRSVPData, error)
+	FindAllUserRSVPHistoryByUserID(ctx context.Context, userID string) ([]*UserRSVPHistoryData, error)
+	FindAllRSVPAttendee(ctx context.Context, filter UserRSVPAttendeeFilter) ([]*UserRSVPAttendeeDetailData, error)
+	FindRSVPAttendeeSummary(ctx context.Context, filter UserRSVPAttendeeFilter) (*UserRSVPAttendeeSummaryData, error)
+	FindAllRSVPWaitlist(ctx context.Context, sessionID string) ([]*UserRSVPWaitlistDetailData, error)
+	PromoteRSVPWaitlist(ctx context.Context, sessionID string) ([]*UserRSVPWaitlistDetailData, error)
+	DeleteRSVPWaitlist(ctx context.Context, id string) ([]*UserRSVPWaitlistDetailData, error)
+	FindOneCheckInDataByID(ctx context.Context, id string) (*UserCheckInData, error)
+	FindAllCheckInData(ctx context.Context, filter UserCheckInFilter) ([]*UserCheckInData, error)
 }
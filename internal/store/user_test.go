@@ -0,0 +1,62 @@
+package store
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestUserCheckInDataAllowedPeople(t *testing.T) {
+	tests := []struct {
+		name   string
+		people sql.NullInt64
+		want   int64
+	}{
+		{name: "no rsvp", want: 1},
+		{name: "declined", people: sql.NullInt64{Int64: 0, Valid: true}, want: 1},
+		{name: "group", people: sql.NullInt64{Int64: 4, Valid: true}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &UserCheckInData{RSVPPeopleCount: tt.people}
+			if got := data.AllowedPeople(); got != tt.want {
+				t.Errorf("AllowedPeople() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserRSVPDataUserStatus(t *testing.T) {
+	tests := []struct {
+		attendance string
+		want       string
+	}{
+		{attendance: UserRSVPAttendanceYes, want: UserStatusRSVPProvided},
+		{attendance: UserRSVPAttendanceNo, want: UserStatusRSVPDeclined},
+		{attendance: UserRSVPAttendanceMaybe, want: UserStatusRSVPTentative},
+		{attendance: "", want: UserStatusRSVPProvided},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.attendance, func(t *testing.T) {
+			data := &UserRSVPData{Attendance: tt.attendance}
+			if got := data.UserStatus(); got != tt.want {
+				t.Errorf("UserStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserCheckInDataDeclined(t *testing.T) {
+	for attendance, want := range map[string]bool{
+		"":                      false,
+		UserRSVPAttendanceYes:   false,
+		UserRSVPAttendanceMaybe: false,
+		UserRSVPAttendanceNo:    true,
+	} {
+		data := &UserCheckInData{RSVPAttendance: attendance}
+		if got := data.Declined(); got != want {
+			t.Errorf("Declined() with attendance %q = %v, want %v", attendance, got, want)
+		}
+	}
+}
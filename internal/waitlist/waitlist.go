@@ -0,0 +1,77 @@
+// Package waitlist lets guests know when seats free up for the RSVP they put on the waitlist.
+package waitlist
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"be-wedding/internal/event"
+	"be-wedding/internal/store"
+	"be-wedding/pkg/whatsapp"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+)
+
+type Notifier struct {
+	whatsAppClient whatsapp.Client
+	broker         *event.Broker
+}
+
+func NewNotifier(whatsAppClient whatsapp.Client, broker *event.Broker) *Notifier {
+	return &Notifier{
+		whatsAppClient: whatsAppClient,
+		broker:         broker,
+	}
+}
+
+// Promoted publishes the RSVPs saved by a waitlist promotion like any other RSVP change, then messages
+// the promoted guests on WhatsApp in the background. A message that fails is logged, the RSVP stands.
+func (n *Notifier) Promoted(promoted []*store.UserRSVPWaitlistDetailData) {
+	if len(promoted) == 0 {
+		return
+	}
+
+	for _, detail := range promoted {
+		action := store.UserRSVPActionCreated
+		changedAt := detail.RSVP.CreatedAt
+		if detail.RSVP.UpdatedAt.Valid {
+			action = store.UserRSVPActionUpdated
+			changedAt = detail.RSVP.UpdatedAt.Time
+		}
+
+		n.broker.Publish(event.TypeRSVP, event.RSVPData{
+			UserRSVPID:  detail.RSVP.ID,
+			UserID:      detail.RSVP.UserID,
+			Action:      action,
+			Attendance:  detail.RSVP.Attendance,
+			PeopleCount: detail.RSVP.PeopleCount,
+			CreatedAt:   changedAt,
+		})
+	}
+
+	go func() {
+		for _, detail := range promoted {
+			text := promotionMessage(detail)
+			err := n.whatsAppClient.SendMessage(context.Background(), detail.WhatsAppNumber, &waProto.Message{Conversation: &text})
+			if err != nil {
+				log.Println("error send waitlist promotion message: %w", err)
+			}
+		}
+	}()
+}
+
+func promotionMessage(detail *store.UserRSVPWaitlistDetailData) string {
+	greeting := "Hi"
+	if detail.UserName != "" {
+		greeting = "Hi " + detail.UserName
+	}
+
+	people := "1 person"
+	if detail.RSVP.PeopleCount != 1 {
+		people = fmt.Sprintf("%d people", detail.RSVP.PeopleCount)
+	}
+
+	return fmt.Sprintf("%s, good news! Seats have opened up for %s and your RSVP for %s is now confirmed. "+
+		"We look forward to celebrating with you.", greeting, detail.SessionName, people)
+}
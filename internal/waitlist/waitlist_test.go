@@ -0,0 +1,43 @@
+package waitlist
+
+import (
+	"testing"
+
+	"be-wedding/internal/store"
+)
+
+func TestPromotionMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail *store.UserRSVPWaitlistDetailData
+		want   string
+	}{
+		{
+			name: "named group",
+			detail: &store.UserRSVPWaitlistDetailData{
+				UserName:    "Budi",
+				SessionName: "Resepsi",
+				RSVP:        &store.UserRSVPData{PeopleCount: 3},
+			},
+			want: "Hi Budi, good news! Seats have opened up for Resepsi and your RSVP for 3 people is now confirmed. " +
+				"We look forward to celebrating with you.",
+		},
+		{
+			name: "unnamed single",
+			detail: &store.UserRSVPWaitlistDetailData{
+				SessionName: "Akad",
+				RSVP:        &store.UserRSVPData{PeopleCount: 1},
+			},
+			want: "Hi, good news! Seats have opened up for Akad and your RSVP for 1 person is now confirmed. " +
+				"We look forward to celebrating with you.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := promotionMessage(tt.detail); got != tt.want {
+				t.Errorf("promotionMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Package pdf writes simple documents of text, rectangles and images without a third party library.
+// Text uses the standard Helvetica fonts every PDF viewer has built in, so only Latin-1 characters print.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// Page sizes in points, portrait.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+	A6Width  = 297.64
+	A6Height = 419.53
+
+	// MM is one millimetre in points.
+	MM = 72 / 25.4
+)
+
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = [...]string{
+	Helvetica:     "Helvetica",
+	HelveticaBold: "Helvetica-Bold",
+}
+
+// The first objects of every document, pages and images follow them.
+const (
+	catalogObject = iota + 1
+	pagesObject
+	fontObject
+)
+
+type Document struct {
+	pages  []*Page
+	images []image.Image
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// Page is drawn in points with the origin at its top left corner and y growing downwards.
+type Page struct {
+	doc     *Document
+	width   float64
+	height  float64
+	content bytes.Buffer
+	// images are indexes into doc.images drawn on this page.
+	images []int
+}
+
+func (d *Document) AddPage(width float64, height float64) *Page {
+	page := &Page{doc: d, width: width, height: height}
+	d.pages = append(d.pages, page)
+
+	return page
+}
+
+func (p *Page) Width() float64 {
+	return p.width
+}
+
+func (p *Page) Height() float64 {
+	return p.height
+}
+
+// Text writes a single line whose baseline starts at x, y.
+func (p *Page) Text(x float64, y float64, font Font, size float64, c color.Color, text string) {
+	fmt.Fprintf(&p.content, "%s rg BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		rgb(c), font+1, num(size), num(x), num(p.height-y), escapeText(encodeText(text)))
+}
+
+// TextCenter writes a single line centred on x.
+func (p *Page) TextCenter(x float64, y float64, font Font, size float64, c color.Color, text string) {
+	p.Text(x-TextWidth(font, size, text)/2, y, font, size, c, text)
+}
+
+func (p *Page) FillRect(x float64, y float64, width float64, height float64, c color.Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		rgb(c), num(x), num(p.height-y-height), num(width), num(height))
+}
+
+// StrokeRect outlines a rectangle, dashed with dashes of the given length unless dash is 0.
+func (p *Page) StrokeRect(x float64, y float64, width float64, height float64, lineWidth float64, c color.Color, dash float64) {
+	dashPattern := "[] 0 d"
+	if dash > 0 {
+		dashPattern = fmt.Sprintf("[%s] 0 d", num(dash))
+	}
+	fmt.Fprintf(&p.content, "q %s RG %s w %s %s %s %s %s re S Q\n",
+		rgb(c), num(lineWidth), dashPattern, num(x), num(p.height-y-height), num(width), num(height))
+}
+
+// Image draws img stretched over the given rectangle. Transparent pixels are flattened onto white.
+func (p *Page) Image(img image.Image, x float64, y float64, width float64, height float64) {
+	idx := len(p.doc.images)
+	p.doc.images = append(p.doc.images, img)
+	p.images = append(p.images, idx)
+
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(width), num(height), num(x), num(p.height-y-height), idx)
+}
+
+// WriteTo encodes the whole document, pages keep the order they were added in.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		return 0, fmt.Errorf("pdf: document has no pages")
+	}
+
+	var buf bytes.Buffer
+	offsets := []int{}
+	beginObject := func() int {
+		offsets = append(offsets, buf.Len())
+		id := len(offsets)
+		fmt.Fprintf(&buf, "%d 0 obj\n", id)
+		return id
+	}
+	endObject := func() {
+		buf.WriteString("\nendobj\n")
+	}
+
+	imageObject := fontObject + len(fontNames)
+	pageObject := imageObject + len(d.images)
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	beginObject()
+	fmt.Fprintf(&buf, "<< /Type /Catalog /Pages %d 0 R >>", pagesObject)
+	endObject()
+
+	beginObject()
+	kids := make([]string, len(d.pages))
+	for idx := range d.pages {
+		kids[idx] = fmt.Sprintf("%d 0 R", pageObject+idx*2)
+	}
+	fmt.Fprintf(&buf, "<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))
+	endObject()
+
+	for _, name := range fontNames {
+		beginObject()
+		fmt.Fprintf(&buf, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name)
+		endObject()
+	}
+
+	for _, img := range d.images {
+		data, err := deflate(rgbPixels(img))
+		if err != nil {
+			return 0, fmt.Errorf("pdf: failed to compress image: %w", err)
+		}
+		beginObject()
+		fmt.Fprintf(&buf, "<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n",
+			img.Bounds().Dx(), img.Bounds().Dy(), len(data))
+		buf.Write(data)
+		buf.WriteString("\nendstream")
+		endObject()
+	}
+
+	for _, page := range d.pages {
+		fonts := make([]string, len(fontNames))
+		for idx := range fontNames {
+			fonts[idx] = fmt.Sprintf("/F%d %d 0 R", idx+1, fontObject+idx)
+		}
+		images := make([]string, len(page.images))
+		for idx, imageIdx := range page.images {
+			images[idx] = fmt.Sprintf("/Im%d %d 0 R", imageIdx, imageObject+imageIdx)
+		}
+
+		id := beginObject()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> /XObject << %s >> >> /Contents %d 0 R >>",
+			pagesObject, num(page.width), num(page.height), strings.Join(fonts, " "), strings.Join(images, " "), id+1)
+		endObject()
+
+		data, err := deflate(page.content.Bytes())
+		if err != nil {
+			return 0, fmt.Errorf("pdf: failed to compress page: %w", err)
+		}
+		beginObject()
+		fmt.Fprintf(&buf, "<< /Filter /FlateDecode /Length %d >>\nstream\n", len(data))
+		buf.Write(data)
+		buf.WriteString("\nendstream")
+		endObject()
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalogObject, xrefOffset)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func rgbPixels(img image.Image) []byte {
+	bounds := img.Bounds()
+	pixels := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			// RGBA is premultiplied, adding the missing alpha composites the pixel over white.
+			r, g, b, a := img.At(x, y).RGBA()
+			pixels = append(pixels, byte((r+0xffff-a)>>8), byte((g+0xffff-a)>>8), byte((b+0xffff-a)>>8))
+		}
+	}
+
+	return pixels
+}
+
+func rgb(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("%s %s %s", num(float64(r)/0xffff), num(float64(g)/0xffff), num(float64(b)/0xffff))
+}
+
+func num(v float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.3f", v), "0")
+	return strings.TrimSuffix(s, ".")
+}
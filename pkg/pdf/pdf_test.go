@@ -0,0 +1,77 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNum(t *testing.T) {
+	tests := map[float64]string{
+		0:        "0",
+		2:        "2",
+		1.5:      "1.5",
+		0.12345:  "0.123",
+		841.89:   "841.89",
+		-12.5001: "-12.5",
+	}
+
+	for in, want := range tests {
+		if got := num(in); got != want {
+			t.Errorf("num(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDocumentWriteTo(t *testing.T) {
+	doc := New()
+	first := doc.AddPage(A6Width, A6Height)
+	first.Text(10, 20, Helvetica, 12, color.Black, "Budi (Table 1)")
+	first.Image(image.NewRGBA(image.Rect(0, 0, 4, 4)), 10, 30, 40, 40)
+	second := doc.AddPage(A4Width, A4Height)
+	second.FillRect(0, 0, 10, 10, color.White)
+	second.StrokeRect(0, 0, 10, 10, 1, color.Black, 2)
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	out := buf.String()
+	if n != int64(len(out)) {
+		t.Errorf("WriteTo() = %d, wrote %d bytes", n, len(out))
+	}
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("WriteTo() is not framed as a PDF")
+	}
+	if !strings.Contains(out, "/Count 2") {
+		t.Error("WriteTo() does not count both pages")
+	}
+
+	// Every xref entry must point at the start of its object.
+	xref := regexp.MustCompile(`(?m)^(\d{10}) 00000 n $`).FindAllStringSubmatch(out, -1)
+	if len(xref) == 0 {
+		t.Fatal("WriteTo() wrote no xref entries")
+	}
+	for idx, entry := range xref {
+		offset, _ := strconv.Atoi(entry[1])
+		if want := strconv.Itoa(idx+1) + " 0 obj\n"; !strings.HasPrefix(out[offset:], want) {
+			t.Errorf("xref entry %d points at %q", idx+1, out[offset:offset+10])
+		}
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	if offset, _ := strconv.Atoi(startxref[1]); !strings.HasPrefix(out[offset:], "xref\n") {
+		t.Error("startxref does not point at the xref table")
+	}
+}
+
+func TestDocumentWriteToWithoutPages(t *testing.T) {
+	if _, err := New().WriteTo(&bytes.Buffer{}); err == nil {
+		t.Error("WriteTo() of an empty document succeeded")
+	}
+}
@@ -0,0 +1,128 @@
+package pdf
+
+import (
+	"strings"
+)
+
+// Glyph widths of the printable ASCII characters 32-126 in thousandths of the font size,
+// taken from the Adobe font metrics of the standard fonts.
+var fontWidths = [...][95]int{
+	Helvetica: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	HelveticaBold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// defaultWidth approximates the accented letters outside ASCII, which are mostly as wide as a lowercase letter.
+const defaultWidth = 556
+
+// winAnsiRunes maps the typographic characters WinAnsiEncoding places in 0x80-0x9f.
+var winAnsiRunes = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// encodeText converts text to WinAnsiEncoding, characters the standard fonts lack become '?'.
+func encodeText(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch b, ok := winAnsiRunes[r]; {
+		case ok:
+			encoded = append(encoded, b)
+		case r >= 32 && r <= 126, r >= 160 && r <= 255:
+			encoded = append(encoded, byte(r))
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
+
+func escapeText(text []byte) string {
+	var escaped strings.Builder
+	for _, b := range text {
+		switch {
+		case b == '(' || b == ')' || b == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(b)
+		case b >= 128:
+			escaped.WriteString("\\")
+			escaped.WriteByte('0' + b>>6)
+			escaped.WriteByte('0' + b>>3&7)
+			escaped.WriteByte('0' + b&7)
+		default:
+			escaped.WriteByte(b)
+		}
+	}
+
+	return escaped.String()
+}
+
+// TextWidth measures a single line in points.
+func TextWidth(font Font, size float64, text string) float64 {
+	width := 0
+	for _, b := range encodeText(text) {
+		if b >= 32 && b <= 126 {
+			width += fontWidths[font][b-32]
+		} else {
+			width += defaultWidth
+		}
+	}
+
+	return float64(width) * size / 1000
+}
+
+// FitText shrinks the font size from size down to minSize until text fits maxWidth,
+// then cuts it short with an ellipsis if it still does not fit.
+func FitText(font Font, size float64, minSize float64, maxWidth float64, text string) (string, float64) {
+	for ; size > minSize; size -= 0.5 {
+		if TextWidth(font, size, text) <= maxWidth {
+			return text, size
+		}
+	}
+	size = minSize
+	if TextWidth(font, size, text) <= maxWidth {
+		return text, size
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && TextWidth(font, size, string(runes)+"…") > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+
+	return strings.TrimSpace(string(runes)) + "…", size
+}
+
+// WrapText breaks text on spaces into lines no wider than maxWidth. A single word wider than
+// maxWidth keeps a line of its own.
+func WrapText(font Font, size float64, maxWidth float64, text string) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && TextWidth(font, size, line+" "+word) > maxWidth {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
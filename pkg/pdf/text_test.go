@@ -0,0 +1,125 @@
+package pdf
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+	}{
+		{in: "Budi & Ani", want: []byte("Budi & Ani")},
+		{in: "Café", want: []byte{'C', 'a', 'f', 0xe9}},
+		{in: "Rp 5.000 – €5…", want: []byte{'R', 'p', ' ', '5', '.', '0', '0', '0', ' ', 0x96, ' ', 0x80, '5', 0x85}},
+		{in: "💍 ✓", want: []byte("? ?")},
+		{in: "", want: []byte{}},
+	}
+
+	for _, tt := range tests {
+		if got := encodeText(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("encodeText(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want string
+	}{
+		{in: []byte("Table 1"), want: "Table 1"},
+		{in: []byte(`(a)\`), want: `\(a\)\\`},
+		{in: []byte{'C', 'a', 'f', 0xe9}, want: `Caf\351`},
+		{in: []byte{0x80}, want: `\200`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		size float64
+		text string
+		want float64
+	}{
+		{font: Helvetica, size: 10, text: "Budi", want: 20.01},
+		{font: HelveticaBold, size: 10, text: "Budi", want: 22.22},
+		{font: Helvetica, size: 20, text: "é", want: 11.12},
+		{font: Helvetica, size: 12, text: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := TextWidth(tt.font, tt.size, tt.text); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("TextWidth(%d, %v, %q) = %v, want %v", tt.font, tt.size, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFitText(t *testing.T) {
+	name := "Keluarga Besar Bapak Budi Santoso"
+	fullWidth := TextWidth(HelveticaBold, 16, name)
+
+	tests := []struct {
+		name         string
+		maxWidth     float64
+		wantText     string
+		wantSize     float64
+		wantEllipsis bool
+	}{
+		{name: "fits at size", maxWidth: fullWidth, wantText: name, wantSize: 16},
+		{name: "shrinks", maxWidth: fullWidth * 0.8, wantText: name, wantSize: 12.5},
+		{name: "cut at min size", maxWidth: fullWidth / 2, wantSize: 10, wantEllipsis: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, size := FitText(HelveticaBold, 16, 10, tt.maxWidth, name)
+			if size != tt.wantSize {
+				t.Errorf("FitText() size = %v, want %v", size, tt.wantSize)
+			}
+			if TextWidth(HelveticaBold, size, text) > tt.maxWidth {
+				t.Errorf("FitText() = %q, wider than %v", text, tt.maxWidth)
+			}
+			if tt.wantEllipsis {
+				if !strings.HasSuffix(text, "…") || !strings.HasPrefix(name, strings.TrimSuffix(text, "…")) {
+					t.Errorf("FitText() = %q, want a shortened name with an ellipsis", text)
+				}
+			} else if text != tt.wantText {
+				t.Errorf("FitText() = %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lineWidth := TextWidth(Helvetica, 10, "Gedung Serbaguna")
+
+	tests := []struct {
+		name     string
+		maxWidth float64
+		text     string
+		want     []string
+	}{
+		{name: "single line", maxWidth: 500, text: "Gedung Serbaguna Melati", want: []string{"Gedung Serbaguna Melati"}},
+		{name: "wraps on spaces", maxWidth: lineWidth, text: "Gedung  Serbaguna Melati Jakarta", want: []string{"Gedung Serbaguna", "Melati Jakarta"}},
+		{name: "long word keeps its own line", maxWidth: 20, text: "di Serbaguna", want: []string{"di", "Serbaguna"}},
+		{name: "empty", maxWidth: lineWidth, text: "   ", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapText(Helvetica, 10, tt.maxWidth, tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE invitation_sessions
+  DROP COLUMN IF EXISTS start_time,
+  DROP COLUMN IF EXISTS end_time,
+  DROP COLUMN IF EXISTS venue,
+  DROP COLUMN IF EXISTS updated_at;
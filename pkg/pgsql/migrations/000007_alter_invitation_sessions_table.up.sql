@@ -0,0 +1,5 @@
+ALTER TABLE invitation_sessions
+  ADD COLUMN IF NOT EXISTS start_time TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS end_time TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS venue TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
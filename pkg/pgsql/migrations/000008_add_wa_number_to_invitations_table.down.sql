@@ -0,0 +1,2 @@
+ALTER TABLE invitations
+  DROP COLUMN IF EXISTS wa_number;
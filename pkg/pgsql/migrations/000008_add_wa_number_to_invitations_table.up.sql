@@ -0,0 +1,2 @@
+ALTER TABLE invitations
+  ADD COLUMN IF NOT EXISTS wa_number TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+ALTER TABLE invitations
+  ADD COLUMN IF NOT EXISTS max_seats INT NOT NULL DEFAULT 1;
+
+-- GROUP invitations had no quota before, they get the GROUP default of 10 seats
+-- (store.InvitationGroupDefaultMaxSeats) or the seats they already hold when that is more.
+-- Hosts should adjust the quota of groups of a different size.
+UPDATE invitations i
+SET max_seats = GREATEST(10, (
+  SELECT COALESCE(SUM(COALESCE((
+    SELECT ursvp.people_count FROM user_rsvps ursvp
+    WHERE ursvp.user_id = u.id
+    ORDER BY ursvp.created_at DESC
+    LIMIT 1
+  ), 1)), 0)
+  FROM users u
+  WHERE u.invitation_id = i.id
+))
+WHERE i.type = 'GROUP';
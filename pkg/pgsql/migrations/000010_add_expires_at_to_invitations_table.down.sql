@@ -0,0 +1,4 @@
+UPDATE invitations SET status = 'AVAILABLE' WHERE status = 'REVOKED';
+
+ALTER TABLE invitations
+  DROP COLUMN IF EXISTS expires_at;
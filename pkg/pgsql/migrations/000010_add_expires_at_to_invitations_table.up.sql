@@ -0,0 +1,2 @@
+ALTER TABLE invitations
+  ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS invitations_code_idx;
+
+ALTER TABLE invitations
+  DROP COLUMN IF EXISTS code;
@@ -0,0 +1,26 @@
+ALTER TABLE invitations
+  ADD COLUMN IF NOT EXISTS code TEXT;
+
+-- Give existing invitations a code from the same alphabet and length as pkg/shortcode.
+DO $$
+DECLARE
+  alphabet CONSTANT TEXT := '23456789ABCDEFGHJKMNPQRSTUVWXYZ';
+  invitation_id TEXT;
+  new_code TEXT;
+BEGIN
+  FOR invitation_id IN SELECT id FROM invitations WHERE code IS NULL LOOP
+    LOOP
+      new_code := '';
+      FOR idx IN 1..7 LOOP
+        new_code := new_code || substr(alphabet, 1 + floor(random() * length(alphabet))::INT, 1);
+      END LOOP;
+      EXIT WHEN NOT EXISTS (SELECT 1 FROM invitations WHERE code = new_code);
+    END LOOP;
+    UPDATE invitations SET code = new_code WHERE id = invitation_id;
+  END LOOP;
+END $$;
+
+ALTER TABLE invitations
+  ALTER COLUMN code SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS invitations_code_idx ON invitations(code);
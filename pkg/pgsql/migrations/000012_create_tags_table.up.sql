@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS tags(
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_idx ON tags(LOWER(name));
+
+CREATE TABLE IF NOT EXISTS invitation_tags(
+  invitation_id TEXT NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+  tag_id TEXT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (invitation_id, tag_id)
+);
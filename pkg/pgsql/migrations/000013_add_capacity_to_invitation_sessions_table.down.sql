@@ -0,0 +1,2 @@
+ALTER TABLE invitation_sessions
+  DROP COLUMN IF EXISTS capacity;
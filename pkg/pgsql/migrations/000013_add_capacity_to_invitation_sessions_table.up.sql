@@ -0,0 +1,3 @@
+-- A NULL capacity means the venue has no seat limit.
+ALTER TABLE invitation_sessions
+  ADD COLUMN IF NOT EXISTS capacity INT CHECK (capacity >= 0);
@@ -0,0 +1,9 @@
+UPDATE users u
+SET status = CASE
+  WHEN EXISTS (SELECT 1 FROM user_rsvps ursvp WHERE ursvp.user_id = u.id) THEN 'RSVP_PROVIDED'
+  WHEN u.name IS NOT NULL THEN 'INFO_COMPLETED'
+  ELSE 'NEWLY_CREATED'
+END
+WHERE u.status = 'CHECKED_IN';
+
+DROP TABLE IF EXISTS check_ins;
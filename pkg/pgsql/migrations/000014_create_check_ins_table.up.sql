@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS check_ins(
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  invitation_id TEXT NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+  people_count INT NOT NULL CHECK (people_count > 0),
+  gate TEXT NOT NULL DEFAULT '',
+  scanned_by TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS check_ins_user_id_idx ON check_ins(user_id);
+CREATE INDEX IF NOT EXISTS check_ins_invitation_id_idx ON check_ins(invitation_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS check_ins_device_scan_idx;
+
+ALTER TABLE check_ins
+  DROP COLUMN IF EXISTS scanned_at,
+  DROP COLUMN IF EXISTS scan_id,
+  DROP COLUMN IF EXISTS device_id;
@@ -0,0 +1,14 @@
+-- Offline gate devices sync their scans later: scanned_at is when the guest was scanned, created_at when it was synced.
+ALTER TABLE check_ins
+  ADD COLUMN IF NOT EXISTS device_id TEXT,
+  ADD COLUMN IF NOT EXISTS scan_id TEXT,
+  ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE check_ins SET scanned_at = created_at WHERE scanned_at IS NULL;
+
+ALTER TABLE check_ins
+  ALTER COLUMN scanned_at SET NOT NULL,
+  ALTER COLUMN scanned_at SET DEFAULT CURRENT_TIMESTAMP;
+
+-- Online check-ins leave both NULL, so only synced scans are deduplicated.
+CREATE UNIQUE INDEX IF NOT EXISTS check_ins_device_scan_idx ON check_ins(device_id, scan_id);
@@ -0,0 +1,3 @@
+-- The table or seat label printed on guest passes, NULL until seating is planned.
+ALTER TABLE invitations
+  ADD COLUMN IF NOT EXISTS table_name VARCHAR(50);
@@ -0,0 +1,26 @@
+-- allowance is the quantity per RSVP'd person for PER_PERSON items and per invitation for PER_INVITATION items.
+-- A NULL stock means the item is not counted.
+CREATE TABLE IF NOT EXISTS redemption_items(
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  allowance_type TEXT NOT NULL CHECK (allowance_type IN ('PER_PERSON', 'PER_INVITATION')),
+  allowance INT NOT NULL CHECK (allowance > 0),
+  stock INT CHECK (stock >= 0),
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS redemption_items_name_idx ON redemption_items(LOWER(name));
+
+CREATE TABLE IF NOT EXISTS redemptions(
+  id TEXT PRIMARY KEY,
+  item_id TEXT NOT NULL REFERENCES redemption_items(id),
+  invitation_id TEXT NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  redeemed_by TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS redemptions_item_id_invitation_id_idx ON redemptions(item_id, invitation_id);
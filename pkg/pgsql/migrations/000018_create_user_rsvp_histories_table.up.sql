@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS user_rsvp_histories(
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  action VARCHAR(10) NOT NULL CHECK (action IN ('CREATED', 'UPDATED', 'CANCELLED')),
+  people_count INT,
+  previous_people_count INT,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS user_rsvp_histories_user_id_idx ON user_rsvp_histories(user_id, created_at);
+
+-- Every RSVP submitted so far becomes a history entry before the older duplicates are removed.
+INSERT INTO user_rsvp_histories(id, user_id, action, people_count, previous_people_count, created_at)
+SELECT id, user_id,
+  CASE WHEN ROW_NUMBER() OVER w = 1 THEN 'CREATED' ELSE 'UPDATED' END,
+  people_count, LAG(people_count) OVER w, created_at
+FROM user_rsvps
+WINDOW w AS (PARTITION BY user_id ORDER BY created_at ASC, id ASC);
+
+DELETE FROM user_rsvps ursvp
+USING user_rsvps newer
+WHERE newer.user_id = ursvp.user_id
+AND (newer.created_at, newer.id) > (ursvp.created_at, ursvp.id);
+
+-- The remaining RSVP keeps the time of the first submission and records the last one as its update.
+UPDATE user_rsvps ursvp
+SET created_at = h.first_created_at, updated_at = ursvp.created_at
+FROM (
+  SELECT user_id, MIN(created_at) AS first_created_at
+  FROM user_rsvp_histories
+  GROUP BY user_id
+  HAVING COUNT(*) > 1
+) h
+WHERE h.user_id = ursvp.user_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_rsvps_user_id_idx ON user_rsvps(user_id);
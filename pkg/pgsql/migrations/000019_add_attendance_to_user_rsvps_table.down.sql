@@ -0,0 +1,9 @@
+UPDATE users SET status = 'RSVP_PROVIDED' WHERE status IN ('RSVP_DECLINED', 'RSVP_TENTATIVE');
+
+ALTER TABLE user_rsvp_histories
+  DROP COLUMN IF EXISTS attendance,
+  DROP COLUMN IF EXISTS decline_message;
+
+ALTER TABLE user_rsvps
+  DROP COLUMN IF EXISTS attendance,
+  DROP COLUMN IF EXISTS decline_message;
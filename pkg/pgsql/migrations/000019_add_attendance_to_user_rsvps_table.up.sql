@@ -0,0 +1,9 @@
+ALTER TABLE user_rsvps
+  ADD COLUMN IF NOT EXISTS attendance VARCHAR(10) NOT NULL DEFAULT 'YES' CHECK (attendance IN ('YES', 'NO', 'MAYBE')),
+  ADD COLUMN IF NOT EXISTS decline_message TEXT;
+
+ALTER TABLE user_rsvp_histories
+  ADD COLUMN IF NOT EXISTS attendance VARCHAR(10),
+  ADD COLUMN IF NOT EXISTS decline_message TEXT;
+
+UPDATE user_rsvp_histories SET attendance = 'YES' WHERE action <> 'CANCELLED';
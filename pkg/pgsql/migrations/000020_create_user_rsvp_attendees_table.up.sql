@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS user_rsvp_attendees(
+  id TEXT PRIMARY KEY,
+  user_rsvp_id TEXT NOT NULL REFERENCES user_rsvps(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  name TEXT NOT NULL,
+  age_group VARCHAR(10) NOT NULL DEFAULT 'ADULT' CHECK (age_group IN ('ADULT', 'CHILD')),
+  dietary_restrictions TEXT[] NOT NULL DEFAULT '{}',
+  wheelchair BOOLEAN NOT NULL DEFAULT FALSE,
+  notes TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_rsvp_attendees_user_rsvp_id_idx ON user_rsvp_attendees(user_rsvp_id, position);
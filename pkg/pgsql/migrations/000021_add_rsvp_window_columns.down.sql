@@ -0,0 +1,6 @@
+ALTER TABLE invitations
+  DROP COLUMN IF EXISTS rsvp_unlocked_until;
+
+ALTER TABLE invitation_sessions
+  DROP COLUMN IF EXISTS rsvp_opens_at,
+  DROP COLUMN IF EXISTS rsvp_closes_at;
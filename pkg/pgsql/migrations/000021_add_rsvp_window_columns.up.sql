@@ -0,0 +1,6 @@
+ALTER TABLE invitation_sessions
+  ADD COLUMN IF NOT EXISTS rsvp_opens_at TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS rsvp_closes_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE invitations
+  ADD COLUMN IF NOT EXISTS rsvp_unlocked_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_rsvp_waitlist_attendees;
+DROP TABLE IF EXISTS user_rsvp_waitlists;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS user_rsvp_waitlists(
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  attendance VARCHAR(5) NOT NULL CHECK (attendance IN ('YES', 'MAYBE')),
+  people_count INT NOT NULL CHECK (people_count > 0),
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_rsvp_waitlists_user_id_idx ON user_rsvp_waitlists(user_id);
+CREATE INDEX IF NOT EXISTS user_rsvp_waitlists_created_at_idx ON user_rsvp_waitlists(created_at, id);
+
+CREATE TABLE IF NOT EXISTS user_rsvp_waitlist_attendees(
+  id TEXT PRIMARY KEY,
+  user_rsvp_waitlist_id TEXT NOT NULL REFERENCES user_rsvp_waitlists(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  name TEXT NOT NULL,
+  age_group VARCHAR(10) NOT NULL DEFAULT 'ADULT' CHECK (age_group IN ('ADULT', 'CHILD')),
+  dietary_restrictions TEXT[] NOT NULL DEFAULT '{}',
+  wheelchair BOOLEAN NOT NULL DEFAULT FALSE,
+  notes TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_rsvp_waitlist_attendees_user_rsvp_waitlist_id_idx ON user_rsvp_waitlist_attendees(user_rsvp_waitlist_id, position);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS plus_one_requests(
+  id TEXT PRIMARY KEY,
+  invitation_id TEXT NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+  decided_by TEXT NOT NULL DEFAULT '',
+  decision_note TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  decided_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS plus_one_requests_user_id_idx ON plus_one_requests(user_id, created_at);
+-- A guest waits for one decision at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS plus_one_requests_pending_idx ON plus_one_requests(user_id) WHERE status = 'PENDING';
@@ -0,0 +1,317 @@
+package qr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	DefaultSize = 256
+	MinSize     = 64
+	MaxSize     = 1024
+
+	defaultForegroundColor = "#6e513b"
+	defaultBackgroundColor = "#ffffff"
+	defaultLogoSize        = 20
+	// maxLogoSize keeps the covered modules within what the high recovery level can restore.
+	maxLogoSize = 30
+)
+
+var recoveryLevels = map[string]qrcode.RecoveryLevel{
+	"low":     qrcode.Low,
+	"medium":  qrcode.Medium,
+	"high":    qrcode.High,
+	"highest": qrcode.Highest,
+}
+
+type Config struct {
+	// StoreImage also writes a PNG to the blob storage on registration, for clients that still read qr_image.
+	// QR codes are otherwise rendered on request.
+	StoreImage bool `toml:"store_image"`
+	// ForegroundColor and BackgroundColor are hex colours such as "#6e513b".
+	ForegroundColor string `toml:"foreground_color"`
+	BackgroundColor string `toml:"background_color"`
+	// Size is the width in pixels used when a request does not ask for one.
+	Size int `toml:"size"`
+	// RecoveryLevel is one of low, medium, high or highest. It is raised to high when a logo is set.
+	RecoveryLevel string `toml:"recovery_level"`
+	DisableBorder bool   `toml:"disable_border"`
+	// LogoPath points to a PNG or JPEG drawn in the centre of the code, e.g. the couple monogram.
+	LogoPath string `toml:"logo_path"`
+	// LogoSize is the logo width as a percentage of the code width, 20 by default and at most 30.
+	LogoSize int `toml:"logo_size"`
+}
+
+// Renderer draws guest QR codes in the configured style.
+type Renderer struct {
+	cfg           Config
+	foreground    color.RGBA
+	background    color.RGBA
+	recoveryLevel qrcode.RecoveryLevel
+	logo          image.Image
+	// logoPNG is the logo re-encoded as PNG for embedding in SVG output.
+	logoPNG []byte
+	// scaledLogos caches the logo scaled to each width drawn so far, keyed by width.
+	scaledLogos sync.Map
+	configHash  string
+}
+
+func NewRenderer(cfg Config) (*Renderer, error) {
+	var err error
+	r := &Renderer{cfg: cfg}
+
+	if r.cfg.ForegroundColor == "" {
+		r.cfg.ForegroundColor = defaultForegroundColor
+	}
+	if r.foreground, err = parseHexColor(r.cfg.ForegroundColor); err != nil {
+		return nil, fmt.Errorf("qr: invalid foreground_color: %w", err)
+	}
+
+	if r.cfg.BackgroundColor == "" {
+		r.cfg.BackgroundColor = defaultBackgroundColor
+	}
+	if r.background, err = parseHexColor(r.cfg.BackgroundColor); err != nil {
+		return nil, fmt.Errorf("qr: invalid background_color: %w", err)
+	}
+
+	if r.cfg.Size == 0 {
+		r.cfg.Size = DefaultSize
+	}
+	if r.cfg.Size < MinSize || r.cfg.Size > MaxSize {
+		return nil, fmt.Errorf("qr: size must be between %d and %d", MinSize, MaxSize)
+	}
+
+	if r.cfg.RecoveryLevel == "" {
+		r.cfg.RecoveryLevel = "medium"
+	}
+	var ok bool
+	if r.recoveryLevel, ok = recoveryLevels[strings.ToLower(r.cfg.RecoveryLevel)]; !ok {
+		return nil, fmt.Errorf("qr: unknown recovery_level %q", r.cfg.RecoveryLevel)
+	}
+
+	if r.cfg.LogoPath != "" {
+		if err := r.loadLogo(); err != nil {
+			return nil, err
+		}
+		// Most requests use the default size, scale the logo for it up front.
+		logoRect, _ := r.logoRect(r.cfg.Size)
+		r.scaledLogo(logoRect.Dx())
+		// The logo hides the centre modules, only high recovery still restores them.
+		if r.recoveryLevel < qrcode.High {
+			r.recoveryLevel = qrcode.High
+		}
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s|%d|%t|%d|", r.cfg.ForegroundColor, r.cfg.BackgroundColor, r.recoveryLevel, r.cfg.DisableBorder, r.cfg.LogoSize)
+	hash.Write(r.logoPNG)
+	r.configHash = hex.EncodeToString(hash.Sum(nil))
+
+	return r, nil
+}
+
+func (r *Renderer) loadLogo() error {
+	if r.cfg.LogoSize == 0 {
+		r.cfg.LogoSize = defaultLogoSize
+	}
+	if r.cfg.LogoSize < 1 || r.cfg.LogoSize > maxLogoSize {
+		return fmt.Errorf("qr: logo_size must be between 1 and %d", maxLogoSize)
+	}
+
+	file, err := os.Open(r.cfg.LogoPath)
+	if err != nil {
+		return fmt.Errorf("qr: failed to open logo: %w", err)
+	}
+	defer file.Close()
+
+	r.logo, _, err = image.Decode(file)
+	if err != nil {
+		return fmt.Errorf("qr: failed to decode logo: %w", err)
+	}
+
+	var logoPNG bytes.Buffer
+	if err := png.Encode(&logoPNG, r.logo); err != nil {
+		return fmt.Errorf("qr: failed to encode logo: %w", err)
+	}
+	r.logoPNG = logoPNG.Bytes()
+
+	return nil
+}
+
+func (r *Renderer) StoreImage() bool {
+	return r.cfg.StoreImage
+}
+
+// Size is the configured default width in pixels.
+func (r *Renderer) Size() int {
+	return r.cfg.Size
+}
+
+// ConfigHash changes whenever the rendering style does, so callers can build cache keys without rendering.
+func (r *Renderer) ConfigHash() string {
+	return r.configHash
+}
+
+func (r *Renderer) newCode(content string) (*qrcode.QRCode, error) {
+	code, err := qrcode.New(content, r.recoveryLevel)
+	if err != nil {
+		return nil, err
+	}
+	code.ForegroundColor = r.foreground
+	code.BackgroundColor = r.background
+	code.DisableBorder = r.cfg.DisableBorder
+
+	return code, nil
+}
+
+// Image draws the code with its logo, for callers that embed it in another document.
+func (r *Renderer) Image(content string, size int) (image.Image, error) {
+	code, err := r.newCode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	qrImage := code.Image(size)
+	if r.logo == nil {
+		return qrImage, nil
+	}
+
+	canvas := image.NewRGBA(qrImage.Bounds())
+	draw.Draw(canvas, canvas.Bounds(), qrImage, qrImage.Bounds().Min, draw.Src)
+	r.drawLogo(canvas)
+
+	return canvas, nil
+}
+
+func (r *Renderer) PNG(content string, size int) ([]byte, error) {
+	if r.logo == nil {
+		code, err := r.newCode(content)
+		if err != nil {
+			return nil, err
+		}
+		return code.PNG(size)
+	}
+
+	canvas, err := r.Image(content, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// logoRect returns the centred square the logo covers in a code of the given width,
+// and the padding kept around it in the background colour.
+func (r *Renderer) logoRect(width int) (image.Rectangle, int) {
+	logoWidth := width * r.cfg.LogoSize / 100
+	pad := logoWidth / 10
+	min := (width - logoWidth) / 2
+
+	return image.Rect(min, min, min+logoWidth, min+logoWidth), pad
+}
+
+func (r *Renderer) drawLogo(canvas *image.RGBA) {
+	logoRect, pad := r.logoRect(canvas.Bounds().Dx())
+	if logoRect.Empty() {
+		return
+	}
+
+	draw.Draw(canvas, logoRect.Inset(-pad), &image.Uniform{C: r.background}, image.Point{}, draw.Src)
+	draw.Draw(canvas, logoRect, r.scaledLogo(logoRect.Dx()), image.Point{}, draw.Over)
+}
+
+// scaledLogo returns the logo scaled to a square of the given width, scaling it only the first time.
+func (r *Renderer) scaledLogo(width int) image.Image {
+	if logo, ok := r.scaledLogos.Load(width); ok {
+		return logo.(image.Image)
+	}
+
+	logo, _ := r.scaledLogos.LoadOrStore(width, scaleImage(r.logo, width, width))
+
+	return logo.(image.Image)
+}
+
+// SVG draws every dark module as a unit square of a single path, scaled to size by the viewBox.
+func (r *Renderer) SVG(content string, size int) ([]byte, error) {
+	code, err := r.newCode(content)
+	if err != nil {
+		return nil, err
+	}
+	bitmap := code.Bitmap()
+	width := len(bitmap)
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, width, width)
+	fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(r.background))
+	fmt.Fprintf(&svg, `<path fill="%s" d="`, hexColor(r.foreground))
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&svg, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	svg.WriteString(`"/>`)
+
+	if r.logo != nil {
+		// Computed at 100 units per module so the logo keeps the same proportions as in the PNG.
+		logoRect, pad := r.logoRect(width * 100)
+		padRect := logoRect.Inset(-pad)
+		fmt.Fprintf(&svg, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`,
+			svgUnit(padRect.Min.X), svgUnit(padRect.Min.Y), svgUnit(padRect.Dx()), svgUnit(padRect.Dy()), hexColor(r.background))
+		fmt.Fprintf(&svg, `<image x="%s" y="%s" width="%s" height="%s" preserveAspectRatio="xMidYMid meet" href="data:image/png;base64,%s"/>`,
+			svgUnit(logoRect.Min.X), svgUnit(logoRect.Min.Y), svgUnit(logoRect.Dx()), svgUnit(logoRect.Dy()),
+			base64.StdEncoding.EncodeToString(r.logoPNG))
+	}
+	svg.WriteString(`</svg>`)
+
+	return []byte(svg.String()), nil
+}
+
+func svgUnit(hundredths int) string {
+	return strconv.FormatFloat(float64(hundredths)/100, 'f', -1, 64)
+}
+
+// hexColor formats c as "#rrggbb", whatever form the config used.
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// parseHexColor accepts "#rgb" and "#rrggbb".
+func parseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.RGBA{}, errors.New("colour must be in #rrggbb format")
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, errors.New("colour must be in #rrggbb format")
+	}
+
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 255}, nil
+}
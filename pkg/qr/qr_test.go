@@ -0,0 +1,129 @@
+package qr
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{in: "#6e513b", want: color.RGBA{R: 0x6e, G: 0x51, B: 0x3b, A: 255}},
+		{in: "6E513B", want: color.RGBA{R: 0x6e, G: 0x51, B: 0x3b, A: 255}},
+		{in: "#fff", want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{in: "#000000", want: color.RGBA{A: 255}},
+		{in: "", wantErr: true},
+		{in: "#ffff", wantErr: true},
+		{in: "#gggggg", wantErr: true},
+		{in: "#+12345", wantErr: true},
+		{in: "red", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseHexColor(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHexColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseHexColor(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRendererRejects(t *testing.T) {
+	tests := map[string]Config{
+		"foreground colour": {ForegroundColor: "brown"},
+		"background colour": {BackgroundColor: "#12"},
+		"size too small":    {Size: MinSize - 1},
+		"size too large":    {Size: MaxSize + 1},
+		"recovery level":    {RecoveryLevel: "extreme"},
+		"missing logo":      {LogoPath: "does-not-exist.png"},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRenderer(cfg); err == nil {
+				t.Errorf("NewRenderer(%+v) succeeded", cfg)
+			}
+		})
+	}
+}
+
+func TestSVGColors(t *testing.T) {
+	r, err := NewRenderer(Config{ForegroundColor: "#ABC", BackgroundColor: "FFFFFF"})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	svg, err := r.SVG("check-in-token", DefaultSize)
+	if err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+
+	for _, want := range []string{`<rect width="100%" height="100%" fill="#ffffff"/>`, `<path fill="#aabbcc" d="`} {
+		if !strings.Contains(string(svg), want) {
+			t.Errorf("SVG() does not contain %s", want)
+		}
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	hash := func(cfg Config) string {
+		r, err := NewRenderer(cfg)
+		if err != nil {
+			t.Fatalf("NewRenderer() error = %v", err)
+		}
+		return r.ConfigHash()
+	}
+
+	base := hash(Config{})
+	if got := hash(Config{Size: 512, StoreImage: true}); got != base {
+		t.Errorf("ConfigHash() changed with the default size or store_image")
+	}
+	if got := hash(Config{ForegroundColor: "#000000"}); got == base {
+		t.Errorf("ConfigHash() did not change with the foreground colour")
+	}
+	if got := hash(Config{DisableBorder: true}); got == base {
+		t.Errorf("ConfigHash() did not change with the border")
+	}
+}
+
+func TestScaledLogo(t *testing.T) {
+	logoPath := filepath.Join(t.TempDir(), "logo.png")
+	logo := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	file, err := os.Create(logoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(file, logo); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	r, err := NewRenderer(Config{LogoPath: logoPath})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	defaultRect, _ := r.logoRect(DefaultSize)
+	if _, ok := r.scaledLogos.Load(defaultRect.Dx()); !ok {
+		t.Error("NewRenderer() did not scale the logo for the default size")
+	}
+	if first, again := r.scaledLogo(30), r.scaledLogo(30); first != again || first.Bounds().Dx() != 30 {
+		t.Error("scaledLogo() scaled the logo again for the same width")
+	}
+
+	if _, err := r.PNG("check-in-token", DefaultSize); err != nil {
+		t.Errorf("PNG() error = %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package qr
+
+import (
+	"image"
+	"image/color"
+)
+
+// scaleImage resizes src to width x height. Each destination pixel averages the source pixels it covers,
+// which keeps a downscaled monogram smooth without pulling in an imaging library.
+func scaleImage(src image.Image, width int, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		srcMinY := bounds.Min.Y + y*bounds.Dy()/height
+		srcMaxY := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if srcMaxY <= srcMinY {
+			srcMaxY = srcMinY + 1
+		}
+
+		for x := 0; x < width; x++ {
+			srcMinX := bounds.Min.X + x*bounds.Dx()/width
+			srcMaxX := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if srcMaxX <= srcMinX {
+				srcMaxX = srcMinX + 1
+			}
+
+			var r, g, b, a, count uint64
+			for sy := srcMinY; sy < srcMaxY; sy++ {
+				for sx := srcMinX; sx < srcMaxX; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+
+	return dst
+}
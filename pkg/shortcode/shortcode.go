@@ -0,0 +1,27 @@
+package shortcode
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// Alphabet leaves out characters that are easily confused when printed or typed: 0/O, 1/I/L.
+const Alphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// DefaultLength gives 31^7 (about 27 billion) possible codes, plenty for a guest list while still short enough to type.
+const DefaultLength = 7
+
+// Generate returns a random code of the given length using Alphabet.
+func Generate(length int) (string, error) {
+	alphabetSize := big.NewInt(int64(len(Alphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = Alphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
@@ -0,0 +1,45 @@
+package shortcode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, length := range []int{0, 1, DefaultLength, 32} {
+		code, err := Generate(length)
+		if err != nil {
+			t.Fatalf("Generate(%d) error = %v", length, err)
+		}
+		if len(code) != length {
+			t.Errorf("Generate(%d) = %q, want %d characters", length, code, length)
+		}
+		for _, r := range code {
+			if !strings.ContainsRune(Alphabet, r) {
+				t.Errorf("Generate(%d) = %q, %q is not in the alphabet", length, code, r)
+			}
+		}
+	}
+}
+
+func TestGenerateVaries(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := Generate(DefaultLength)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		seen[code] = true
+	}
+	if len(seen) < 99 {
+		t.Errorf("Generate() returned %d distinct codes out of 100", len(seen))
+	}
+}
+
+func TestAlphabetHasNoConfusableCharacters(t *testing.T) {
+	for _, r := range "01ILO" {
+		if strings.ContainsRune(Alphabet, r) {
+			t.Errorf("Alphabet contains %q", r)
+		}
+	}
+}
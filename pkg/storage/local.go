@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type LocalConfig struct {
+	// Dir is the directory the objects are written to.
+	Dir string
+	// BaseURL is where Dir is served from, e.g. by the API's /static route.
+	BaseURL string
+}
+
+type local struct {
+	cfg LocalConfig
+}
+
+func NewLocal(cfg LocalConfig) (Storage, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("storage: local dir is required")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: failed to create local dir: %w", err)
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+
+	return &local{cfg: cfg}, nil
+}
+
+// filePath maps a key into Dir, rejecting keys that would escape it.
+func (s *local) filePath(key string) (string, error) {
+	cleanKey := path.Clean("/" + key)
+	if cleanKey == "/" {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+
+	return filepath.Join(s.cfg.Dir, filepath.FromSlash(cleanKey)), nil
+}
+
+// Put writes to a temporary file first, so a reader never sees a partially written object.
+func (s *local) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("storage: failed to create dir: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("storage: failed to create file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, r); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("storage: failed to write file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("storage: failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmpFile.Name(), 0o644); err != nil {
+		return fmt.Errorf("storage: failed to write file: %w", err)
+	}
+
+	return os.Rename(tmpFile.Name(), filePath)
+}
+
+func (s *local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+func (s *local) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("storage: failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *local) URL(key string) string {
+	return s.cfg.BaseURL + "/" + strings.TrimPrefix(key, "/")
+}
@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultS3URLExpiry is used for presigned links when url_expiry is not set.
+const defaultS3URLExpiry = 60 * time.Minute
+
+type S3Config struct {
+	// Endpoint of an S3-compatible service, e.g. "https://s3.ap-southeast-1.amazonaws.com" or "http://minio:9000".
+	Endpoint string
+	Region   string
+	Bucket   string
+	// AccessKeyID and SecretAccessKey fall back to the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// environment variables, so the credentials can stay out of the config file.
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses the bucket as endpoint/bucket instead of bucket.endpoint, MinIO needs it.
+	UsePathStyle bool
+	// PublicURL serves objects from a public bucket or CDN. When empty, URL returns presigned links.
+	PublicURL string
+	// URLExpiry is how long presigned links stay valid, in minutes.
+	URLExpiry int
+}
+
+// s3 talks to the S3 REST API directly and signs requests with AWS Signature Version 4.
+type s3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func NewS3(cfg S3Config) (Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("storage: s3 endpoint, region and bucket are required")
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage: invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	if cfg.AccessKeyID == "" && cfg.SecretAccessKey == "" {
+		cfg.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		cfg.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("storage: s3 access key id and secret access key are required")
+	}
+
+	return &s3{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// objectURL returns the unsigned URL of an object in the configured addressing style.
+func (s *s3) objectURL(key string) *url.URL {
+	objectURL := *s.endpoint
+	escapedKey := s3EscapePath(strings.TrimPrefix(key, "/"))
+	if s.cfg.UsePathStyle {
+		objectURL.Path = "/" + s.cfg.Bucket + "/" + strings.TrimPrefix(key, "/")
+		objectURL.RawPath = "/" + s3EscapePath(s.cfg.Bucket) + "/" + escapedKey
+	} else {
+		objectURL.Host = s.cfg.Bucket + "." + objectURL.Host
+		objectURL.Path = "/" + strings.TrimPrefix(key, "/")
+		objectURL.RawPath = "/" + escapedKey
+	}
+
+	return &objectURL
+}
+
+func (s *s3) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("storage: failed to read object: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return s3ResponseError(resp)
+}
+
+func (s *s3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s3ResponseError(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *s3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 204 whether or not the object existed.
+	return s3ResponseError(resp)
+}
+
+func (s *s3) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return s.cfg.PublicURL + "/" + s3EscapePath(strings.TrimPrefix(key, "/"))
+	}
+
+	expiry := defaultS3URLExpiry
+	if s.cfg.URLExpiry > 0 {
+		expiry = time.Duration(s.cfg.URLExpiry) * time.Minute
+	}
+
+	return s.presign(http.MethodGet, key, time.Now().UTC(), expiry)
+}
+
+func (s *s3) do(ctx context.Context, method string, key string, body []byte, contentType string) (*http.Response, error) {
+	objectURL := s.objectURL(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to create s3 request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: s3 %s %s: %w", method, key, err)
+	}
+
+	return resp, nil
+}
+
+func s3ResponseError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf("storage: s3 responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3DateLayout      = "20060102"
+	s3TimeLayout      = "20060102T150405Z"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// sign adds the Authorization header of AWS Signature Version 4 to the request.
+func (s *s3) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeLayout))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-date":           req.Header.Get("X-Amz-Date"),
+		"x-amz-content-sha256": payloadHash,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	signature := s.signature(now, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// presign returns a URL carrying its signature in the query string, valid for the given duration.
+func (s *s3) presign(method string, key string, now time.Time, expiry time.Duration) string {
+	objectURL := s.objectURL(key)
+	scope := s.scope(now)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.cfg.AccessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(s3TimeLayout))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		objectURL.EscapedPath(),
+		s3CanonicalQuery(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, scope, canonicalRequest))
+	objectURL.RawQuery = s3CanonicalQuery(query)
+
+	return objectURL.String()
+}
+
+func (s *s3) scope(now time.Time) string {
+	return now.Format(s3DateLayout) + "/" + s.cfg.Region + "/" + s3Service + "/aws4_request"
+}
+
+func (s *s3) signature(now time.Time, scope string, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3TimeLayout),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), now.Format(s3DateLayout))
+	signingKey = hmacSHA256(signingKey, s.cfg.Region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+// s3CanonicalQuery sorts the parameters and encodes them the way Signature Version 4 expects.
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, s3Escape(key, true)+"="+s3Escape(value, true))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+func s3EscapePath(p string) string {
+	return s3Escape(p, false)
+}
+
+// s3Escape percent-encodes everything but the RFC 3986 unreserved characters, and the slash when escapeSlash is false.
+func s3Escape(s string, escapeSlash bool) string {
+	var escaped strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			escaped.WriteByte(b)
+		case b == '/' && !escapeSlash:
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
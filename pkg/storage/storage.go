@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var ErrNotFound = errors.New("storage: object not found")
+
+type Config struct {
+	Backend string
+	Local   LocalConfig
+	S3      S3Config
+}
+
+// Storage keeps blobs such as QR images outside the API process, so every replica sees the same files.
+// Keys are slash separated paths relative to the root of the backend.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns a link clients can download the object from.
+	URL(key string) string
+}
+
+// New returns the backend selected in the config, the local filesystem when none is set.
+func New(cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case "", BackendLocal:
+		return NewLocal(cfg.Local)
+	case BackendS3:
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("storage: unknown backend %q", cfg.Backend)
+	}
+}
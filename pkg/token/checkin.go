@@ -0,0 +1,155 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// CheckInAudience marks tokens meant for the door scanner, so an access token signed
+// with the same secret is not accepted as a check-in code and vice versa.
+const CheckInAudience = "check-in"
+
+// CheckInManifestAudience marks the manifest gate devices download to validate scans offline.
+const CheckInManifestAudience = "check-in-manifest"
+
+// checkInManifestTTL covers the event day; devices download a fresh manifest before each session.
+const checkInManifestTTL = 24 * time.Hour
+
+// checkInTokenHashSize keeps manifest entries short while collisions stay out of reach for a guest list.
+const checkInTokenHashSize = 12
+
+var (
+	ErrInvalidCheckInToken = errors.New("invalid check-in token")
+	ErrNoManifestKey       = errors.New("check-in manifest key is not configured")
+)
+
+// CheckInClaim is carried by the QR code a guest shows at the door.
+// It does not expire, the guest keeps the same QR until the event.
+type CheckInClaim struct {
+	jwt.StandardClaims
+	UserID       string `json:"user_id"`
+	InvitationID string `json:"invitation_id"`
+}
+
+func (j *jwtImpl) CreateCheckInToken(claim CheckInClaim) (string, error) {
+	claim.StandardClaims = jwt.StandardClaims{
+		Audience: CheckInAudience,
+		Subject:  claim.UserID,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
+
+	signedToken, err := token.SignedString([]byte(j.cfg.Secret))
+	if err != nil {
+		return "", fmt.Errorf("Error creating check-in token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+// GetCheckInClaims verifies the signature of a scanned check-in token and returns its claim.
+// Any token that is malformed, signed with another secret or not issued for check-in
+// returns ErrInvalidCheckInToken.
+func (j *jwtImpl) GetCheckInClaims(tokenString string) (*CheckInClaim, error) {
+	claim := &CheckInClaim{}
+	_, err := jwt.ParseWithClaims(tokenString, claim, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(j.cfg.Secret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCheckInToken, err)
+	}
+
+	if !claim.VerifyAudience(CheckInAudience, true) || claim.UserID == "" || claim.Subject != claim.UserID {
+		return nil, ErrInvalidCheckInToken
+	}
+
+	return claim, nil
+}
+
+// CheckInManifestEntry lets a device recognise a QR payload by its hash without knowing the secret.
+// The JSON keys are kept to one letter since the manifest lists every guest.
+type CheckInManifestEntry struct {
+	Hash            string `json:"h"`
+	UserID          string `json:"u"`
+	Name            string `json:"n"`
+	SessionID       string `json:"s"`
+	AllowedPeople   int64  `json:"p"`
+	CheckedInPeople int64  `json:"c"`
+}
+
+type CheckInManifestClaim struct {
+	jwt.StandardClaims
+	Entries []CheckInManifestEntry `json:"entries"`
+}
+
+// CheckInTokenHash returns the hash a manifest entry carries for a check-in token.
+// Check-in tokens have no timestamp, so the hash of a guest's QR payload never changes.
+func CheckInTokenHash(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:checkInTokenHashSize])
+}
+
+// manifestKey decodes Config.ManifestKey, the base64 seed of the Ed25519 key signing manifests.
+func (j *jwtImpl) manifestKey() (ed25519.PrivateKey, error) {
+	if j.cfg.ManifestKey == "" {
+		return nil, ErrNoManifestKey
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(j.cfg.ManifestKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("check-in manifest key must be a base64 encoded %d byte Ed25519 seed", ed25519.SeedSize)
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// CheckInManifestPublicKey returns the key gate devices verify manifests with.
+func (j *jwtImpl) CheckInManifestPublicKey() (ed25519.PublicKey, error) {
+	privateKey, err := j.manifestKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey.Public().(ed25519.PublicKey), nil
+}
+
+// CreateCheckInManifest signs the manifest with EdDSA rather than the HMAC secret of the other tokens,
+// so a device holding the public key can verify it offline without being able to sign anything.
+func (j *jwtImpl) CreateCheckInManifest(entries []CheckInManifestEntry) (string, time.Time, error) {
+	privateKey, err := j.manifestKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expAt := now.Add(checkInManifestTTL)
+
+	claim := CheckInManifestClaim{
+		StandardClaims: jwt.StandardClaims{
+			Audience:  CheckInManifestAudience,
+			ExpiresAt: expAt.Unix(),
+			IssuedAt:  now.Unix(),
+		},
+		Entries: entries,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claim)
+
+	signedToken, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("Error creating check-in manifest: %w", err)
+	}
+
+	return signedToken, expAt, nil
+}
@@ -0,0 +1,121 @@
+package token
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testManifestKey = "N2Y0Yzk1ZmUxNTlhNGY4ZmI2YjQzZWQwYTM2ZDg5MjE="
+
+func TestCheckInToken(t *testing.T) {
+	j := NewJWT(Config{Secret: "secret", ExpTime: 30})
+
+	checkInToken, err := j.CreateCheckInToken(CheckInClaim{UserID: "u1", InvitationID: "i1"})
+	if err != nil {
+		t.Fatalf("CreateCheckInToken() error = %v", err)
+	}
+
+	claim, err := j.GetCheckInClaims(checkInToken)
+	if err != nil {
+		t.Fatalf("GetCheckInClaims() error = %v", err)
+	}
+	if claim.UserID != "u1" || claim.InvitationID != "i1" {
+		t.Errorf("GetCheckInClaims() = %+v", claim)
+	}
+
+	again, _ := j.CreateCheckInToken(CheckInClaim{UserID: "u1", InvitationID: "i1"})
+	if again != checkInToken || CheckInTokenHash(again) != CheckInTokenHash(checkInToken) {
+		t.Error("CreateCheckInToken() is not stable for the same user")
+	}
+}
+
+func TestGetCheckInClaimsRejects(t *testing.T) {
+	j := NewJWT(Config{Secret: "secret", ExpTime: 30})
+	other := NewJWT(Config{Secret: "other", ExpTime: 30})
+
+	accessToken, err := j.CreateAccessToken(JWTClaim{InvitationID: "i1"})
+	if err != nil {
+		t.Fatalf("CreateAccessToken() error = %v", err)
+	}
+	otherToken, err := other.CreateCheckInToken(CheckInClaim{UserID: "u1"})
+	if err != nil {
+		t.Fatalf("CreateCheckInToken() error = %v", err)
+	}
+
+	tests := map[string]string{
+		"access token":   accessToken.Token,
+		"another secret": otherToken,
+		"garbage":        "not-a-token",
+		"unsigned":       "eyJhbGciOiJub25lIn0.eyJhdWQiOiJjaGVjay1pbiIsInN1YiI6InUxIiwidXNlcl9pZCI6InUxIn0.",
+		"empty":          "",
+	}
+	for name, tokenString := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := j.GetCheckInClaims(tokenString); !errors.Is(err, ErrInvalidCheckInToken) {
+				t.Errorf("GetCheckInClaims() error = %v, want ErrInvalidCheckInToken", err)
+			}
+		})
+	}
+}
+
+func TestCheckInManifest(t *testing.T) {
+	j := NewJWT(Config{Secret: "secret", ExpTime: 30, ManifestKey: testManifestKey})
+
+	manifest, _, err := j.CreateCheckInManifest([]CheckInManifestEntry{{Hash: "h1", UserID: "u1"}})
+	if err != nil {
+		t.Fatalf("CreateCheckInManifest() error = %v", err)
+	}
+
+	publicKey, err := j.CheckInManifestPublicKey()
+	if err != nil {
+		t.Fatalf("CheckInManifestPublicKey() error = %v", err)
+	}
+
+	claim := &CheckInManifestClaim{}
+	_, err = jwt.ParseWithClaims(manifest, claim, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			t.Errorf("manifest signed with %v, want EdDSA", token.Header["alg"])
+		}
+		return publicKey, nil
+	})
+	if err != nil {
+		t.Fatalf("manifest does not verify with the public key: %v", err)
+	}
+	if len(claim.Entries) != 1 || claim.Entries[0].Hash != "h1" || !claim.VerifyAudience(CheckInManifestAudience, true) {
+		t.Errorf("manifest claim = %+v", claim)
+	}
+
+	if _, err := j.GetCheckInClaims(manifest); !errors.Is(err, ErrInvalidCheckInToken) {
+		t.Errorf("GetCheckInClaims(manifest) error = %v, want ErrInvalidCheckInToken", err)
+	}
+}
+
+func TestCheckInManifestKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifestKey string
+		wantErr     bool
+	}{
+		{name: "valid seed", manifestKey: testManifestKey},
+		{name: "not configured", wantErr: true},
+		{name: "not base64", manifestKey: "not base64!", wantErr: true},
+		{name: "wrong length", manifestKey: "c2hvcnQ=", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJWT(Config{Secret: "secret", ExpTime: 30, ManifestKey: tt.manifestKey})
+
+			_, _, err := j.CreateCheckInManifest(nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateCheckInManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, err = j.CheckInManifestPublicKey()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckInManifestPublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
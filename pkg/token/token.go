@@ -1,6 +1,8 @@
 package token
 
 import (
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,15 +10,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// AccessAudience marks the access and refresh tokens, so a check-in token signed with the
+// same secret is not accepted by GetClaims.
+const AccessAudience = "access"
+
+var ErrInvalidAccessToken = errors.New("invalid access token")
+
 type Config struct {
 	Secret  string `toml:"secret"`
 	ExpTime int    `toml:"exp_time"`
+	// ManifestKey is the base64 seed of the Ed25519 key signing the offline check-in manifest.
+	ManifestKey string `toml:"manifest_key"`
 }
 
 type JWT interface {
 	CreateAccessToken(claim JWTClaim) (*JWTToken, error)
 	CreateRefreshToken(claim JWTClaim) (*JWTToken, error)
 	GetClaims(token string) (*JWTClaim, error)
+	CreateCheckInToken(claim CheckInClaim) (string, error)
+	GetCheckInClaims(token string) (*CheckInClaim, error)
+	CreateCheckInManifest(entries []CheckInManifestEntry) (string, time.Time, error)
+	CheckInManifestPublicKey() (ed25519.PublicKey, error)
 }
 
 type JWTClaim struct {
@@ -64,6 +78,7 @@ func (j *jwtImpl) CreateAccessToken(claim JWTClaim) (*JWTToken, error) {
 	iat := now.Unix()
 
 	stdClaim := jwt.StandardClaims{
+		Audience:  AccessAudience,
 		ExpiresAt: exp,
 		IssuedAt:  iat,
 	}
@@ -96,6 +111,7 @@ func (j *jwtImpl) CreateRefreshToken(claim JWTClaim) (*JWTToken, error) {
 
 	stdClaim := jwt.StandardClaims{
 		Id:        uuid.String(),
+		Audience:  AccessAudience,
 		ExpiresAt: exp,
 		IssuedAt:  iat,
 	}
@@ -131,5 +147,9 @@ func (j *jwtImpl) GetClaims(tokenString string) (*JWTClaim, error) {
 		return nil, err
 	}
 
+	if !claim.VerifyAudience(AccessAudience, true) {
+		return nil, ErrInvalidAccessToken
+	}
+
 	return claim, nil
 }
@@ -0,0 +1,43 @@
+package token
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetClaims(t *testing.T) {
+	j := NewJWT(Config{Secret: "secret", ExpTime: 30})
+
+	accessToken, err := j.CreateAccessToken(JWTClaim{InvitationID: "i1", Type: "GROUP"})
+	if err != nil {
+		t.Fatalf("CreateAccessToken() error = %v", err)
+	}
+	claim, err := j.GetClaims(accessToken.Token)
+	if err != nil {
+		t.Fatalf("GetClaims(access token) error = %v", err)
+	}
+	if claim.InvitationID != "i1" || claim.Type != "GROUP" {
+		t.Errorf("GetClaims(access token) = %+v", claim)
+	}
+
+	refreshToken, err := j.CreateRefreshToken(JWTClaim{InvitationID: "i1"})
+	if err != nil {
+		t.Fatalf("CreateRefreshToken() error = %v", err)
+	}
+	if _, err = j.GetClaims(refreshToken.Token); err != nil {
+		t.Errorf("GetClaims(refresh token) error = %v", err)
+	}
+
+	checkInToken, err := j.CreateCheckInToken(CheckInClaim{UserID: "u1", InvitationID: "i1"})
+	if err != nil {
+		t.Fatalf("CreateCheckInToken() error = %v", err)
+	}
+	if _, err = j.GetClaims(checkInToken); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Errorf("GetClaims(check-in token) error = %v, want ErrInvalidAccessToken", err)
+	}
+
+	other := NewJWT(Config{Secret: "other", ExpTime: 30})
+	if _, err = other.GetClaims(accessToken.Token); err == nil {
+		t.Error("GetClaims() accepted a token signed with another secret")
+	}
+}
@@ -0,0 +1,35 @@
+package whatsapp
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidNumber = errors.New("whatsapp number must contain 8 to 15 digits")
+
+// NormalizeNumber converts a phone number as typed by a person (e.g. "0812-3456 789" or "+62 812 3456 789")
+// into the international digits-only form used for WhatsApp JIDs (e.g. "628123456789").
+// Local Indonesian numbers starting with 0 are assumed to use the 62 country code.
+func NormalizeNumber(number string) (string, error) {
+	var digits strings.Builder
+	for _, r := range strings.TrimSpace(number) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '+':
+			continue
+		default:
+			return "", ErrInvalidNumber
+		}
+	}
+
+	normalized := digits.String()
+	if strings.HasPrefix(normalized, "0") {
+		normalized = "62" + strings.TrimPrefix(normalized, "0")
+	}
+	if len(normalized) < 8 || len(normalized) > 15 {
+		return "", ErrInvalidNumber
+	}
+
+	return normalized, nil
+}
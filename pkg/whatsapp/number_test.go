@@ -0,0 +1,37 @@
+package whatsapp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := []struct {
+		number  string
+		want    string
+		wantErr bool
+	}{
+		{number: "0812-3456 789", want: "628123456789"},
+		{number: "+62 812 3456 789", want: "628123456789"},
+		{number: "(021) 555.1234", want: "62215551234"},
+		{number: "  6281234567890  ", want: "6281234567890"},
+		{number: "+1 415 555 2671", want: "14155552671"},
+		{number: "0812abc", wantErr: true},
+		{number: "1234567", wantErr: true},
+		{number: "1234567890123456", wantErr: true},
+		{number: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeNumber(tt.number)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidNumber) {
+				t.Errorf("NormalizeNumber(%q) = %q, %v, want ErrInvalidNumber", tt.number, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeNumber(%q) = %q, %v, want %q", tt.number, got, err, tt.want)
+		}
+	}
+}